@@ -0,0 +1,9 @@
+data "sonarqube_current_user" "current" {}
+
+# Stop the plan early when the credentials cannot manage quality gates
+check "quality_gate_permission" {
+  assert {
+    condition     = contains(data.sonarqube_current_user.current.global_permissions, "gateadmin")
+    error_message = "The credentials of ${data.sonarqube_current_user.current.login} lack the gateadmin permission."
+  }
+}
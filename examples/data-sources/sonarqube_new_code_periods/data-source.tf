@@ -0,0 +1,3 @@
+data "sonarqube_new_code_periods" "new_code_periods" {
+  project = "my-project"
+}
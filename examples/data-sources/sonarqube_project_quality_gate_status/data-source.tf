@@ -0,0 +1,14 @@
+data "sonarqube_project_quality_gate_status" "main" {
+  project = "my-project"
+  branch  = "main"
+}
+
+# Refuse to deploy when the latest analysis failed its quality gate
+resource "terraform_data" "deployment" {
+  lifecycle {
+    precondition {
+      condition     = data.sonarqube_project_quality_gate_status.main.status == "OK"
+      error_message = "The quality gate of my-project failed."
+    }
+  }
+}
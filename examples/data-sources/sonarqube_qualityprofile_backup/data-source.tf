@@ -0,0 +1,11 @@
+data "sonarqube_qualityprofile_backup" "staging" {
+  provider = sonarqube.staging
+  name     = "my-java-profile"
+  language = "java"
+}
+
+# Promote the profile from staging to production
+resource "sonarqube_qualityprofile_restore" "production" {
+  provider = sonarqube.production
+  backup   = data.sonarqube_qualityprofile_backup.staging.backup
+}
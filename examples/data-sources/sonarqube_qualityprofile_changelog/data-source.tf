@@ -0,0 +1,13 @@
+data "sonarqube_qualityprofile_changelog" "last_month" {
+  name     = "my-java-profile"
+  language = "java"
+  since    = "2024-01-01"
+  to       = "2024-02-01"
+}
+
+output "deactivated_rules" {
+  value = [
+    for event in data.sonarqube_qualityprofile_changelog.last_month.events : event.rule_key
+    if event.action == "DEACTIVATED"
+  ]
+}
@@ -0,0 +1,18 @@
+data "sonarqube_qualityprofile" "candidate" {
+  name     = "my-java-profile-candidate"
+  language = "java"
+}
+
+data "sonarqube_qualityprofile" "current" {
+  name     = "my-java-profile"
+  language = "java"
+}
+
+data "sonarqube_qualityprofile_compare" "promotion" {
+  left_key  = data.sonarqube_qualityprofile.current.key
+  right_key = data.sonarqube_qualityprofile.candidate.key
+}
+
+output "rules_added_by_promotion" {
+  value = data.sonarqube_qualityprofile_compare.promotion.in_right[*].key
+}
@@ -1,3 +1,16 @@
 data "sonarqube_users" "users" {
 
 }
+
+# Users which did not connect during the last 180 days, including those which never connected
+data "sonarqube_users" "idle" {
+  managed               = false
+  last_connected_before = timeadd(plantimestamp(), "-4320h")
+}
+
+output "idle_administrators" {
+  value = [
+    for user in data.sonarqube_users.idle.users : user.login_name
+    if contains(user.groups, "sonar-administrators")
+  ]
+}
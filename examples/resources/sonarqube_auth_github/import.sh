@@ -0,0 +1,2 @@
+# Import the GitHub authentication using the id of the configuration, or "github" on servers older than 10.5
+terraform import sonarqube_auth_github.main 4fd8e4ab-1b4f-4b6c-9b1e-1b2f0bb1b4a1
@@ -0,0 +1,9 @@
+resource "sonarqube_auth_github" "main" {
+  client_id             = var.github_client_id
+  client_secret         = var.github_client_secret
+  application_id        = "123456"
+  private_key           = file("github-app.private-key.pem")
+  allowed_organizations = ["my-organization"]
+  synchronize_groups    = true
+  provisioning_type     = "AUTO_PROVISIONING"
+}
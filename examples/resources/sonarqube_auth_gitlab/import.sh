@@ -0,0 +1,2 @@
+# Import the GitLab authentication using the id of the configuration, or "gitlab" on servers older than 10.5
+terraform import sonarqube_auth_gitlab.main 7e2d2bf6-1e4a-4e5e-b1b5-0b9b3e0a7c1d
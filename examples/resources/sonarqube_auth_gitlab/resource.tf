@@ -0,0 +1,12 @@
+resource "sonarqube_auth_gitlab" "main" {
+  url            = "https://gitlab.example.org"
+  application_id = var.gitlab_application_id
+  secret         = var.gitlab_secret
+}
+
+# allowed_groups and synchronize_groups are left unset on sonarqube_auth_gitlab, so that they are managed here
+resource "sonarqube_gitlab_synchronized_groups" "main" {
+  allowed_groups = ["my-company"]
+
+  depends_on = [sonarqube_auth_gitlab.main]
+}
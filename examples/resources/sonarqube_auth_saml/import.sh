@@ -0,0 +1,2 @@
+# There is a single SAML configuration, which is imported with the id "saml"
+terraform import sonarqube_auth_saml.main saml
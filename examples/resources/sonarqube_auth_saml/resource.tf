@@ -0,0 +1,10 @@
+resource "sonarqube_auth_saml" "main" {
+  provider_name        = "Keycloak"
+  provider_id          = "https://keycloak.example.org/realms/sonarqube"
+  login_url            = "https://keycloak.example.org/realms/sonarqube/protocol/saml"
+  certificate          = file("idp-certificate.pem")
+  user_login_attribute = "login"
+  user_name_attribute  = "name"
+  user_email_attribute = "email"
+  group_attribute      = "groups"
+}
@@ -0,0 +1,2 @@
+# Import the permission mapping of a GitHub role using the name of the role
+terraform import sonarqube_github_permission_mapping.triage triage
@@ -0,0 +1,11 @@
+# Base roles always exist, only their permissions are updated
+resource "sonarqube_github_permission_mapping" "triage" {
+  github_role = "triage"
+  permissions = ["user", "codeviewer", "issueadmin"]
+}
+
+# Custom roles of the GitHub organization are created and deleted
+resource "sonarqube_github_permission_mapping" "security_reviewer" {
+  github_role = "security-reviewer"
+  permissions = ["user", "codeviewer", "securityhotspotadmin"]
+}
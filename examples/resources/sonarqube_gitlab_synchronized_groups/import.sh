@@ -0,0 +1,2 @@
+# Import the synchronized groups using the id of the GitLab configuration
+terraform import sonarqube_gitlab_synchronized_groups.main 7e2d2bf6-1e4a-4e5e-b1b5-0b9b3e0a7c1d
@@ -0,0 +1,4 @@
+resource "sonarqube_gitlab_synchronized_groups" "main" {
+  allowed_groups     = ["my-company", "my-company/contractors"]
+  synchronize_groups = true
+}
@@ -0,0 +1,2 @@
+# Import the members of a group using the name of the group
+terraform import sonarqube_group_members.project_admins Project-Admins
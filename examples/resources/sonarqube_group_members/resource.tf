@@ -0,0 +1,27 @@
+resource "sonarqube_user" "alice" {
+  login_name = "alice"
+  name       = "Alice"
+  email      = "alice@example.org"
+  is_local   = false
+}
+
+resource "sonarqube_user" "bob" {
+  login_name = "bob"
+  name       = "Bob"
+  email      = "bob@example.org"
+  is_local   = false
+}
+
+resource "sonarqube_group" "project_admins" {
+  name        = "Project-Admins"
+  description = "This is a group"
+}
+
+# Any other member of the group is removed, and members added by hand show up in the plan
+resource "sonarqube_group_members" "project_admins" {
+  name = sonarqube_group.project_admins.name
+  login_names = [
+    sonarqube_user.alice.login_name,
+    sonarqube_user.bob.login_name,
+  ]
+}
@@ -0,0 +1,2 @@
+# Import a condition using the name of the quality gate and the metric of the condition
+terraform import sonarqube_qualitygate_condition.coverage example-team/coverage
@@ -0,0 +1,11 @@
+resource "sonarqube_qualitygate" "main" {
+  name      = "example-team"
+  copy_from = "Sonar way"
+}
+
+resource "sonarqube_qualitygate_condition" "coverage" {
+  gatename  = sonarqube_qualitygate.main.name
+  metric    = "coverage"
+  op        = "LT"
+  threshold = "60"
+}
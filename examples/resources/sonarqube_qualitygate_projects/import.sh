@@ -0,0 +1,2 @@
+# Import the projects associated to a quality gate using the name of the quality gate
+terraform import sonarqube_qualitygate_projects.main my_qualitygate
@@ -0,0 +1,21 @@
+resource "sonarqube_qualitygate" "main" {
+  name = "my_qualitygate"
+
+  condition {
+    metric    = "new_coverage"
+    op        = "LT"
+    threshold = "50"
+  }
+}
+
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_qualitygate_projects" "main" {
+  gatename      = sonarqube_qualitygate.main.name
+  authoritative = true
+  projects      = [sonarqube_project.main.project]
+}
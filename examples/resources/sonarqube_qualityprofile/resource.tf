@@ -4,3 +4,9 @@ resource "sonarqube_qualityprofile" "main" {
   is_default = false
   parent     = "sonar way"
 }
+
+resource "sonarqube_qualityprofile" "copy" {
+  name      = "example-copy"
+  language  = "js"
+  copy_from = sonarqube_qualityprofile.main.name
+}
@@ -21,3 +21,12 @@ resource "sonarqube_qualityprofile_activate_rule" "xml_rule" {
   rule     = sonarqube_rule.allowed_maven_dependencies.id
   severity = "BLOCKER"
 }
+
+# SonarQube 10.8+: override the impact severities of the rule instead of its legacy severity
+resource "sonarqube_qualityprofile_activate_rule" "xml_rule_impacts" {
+  key  = sonarqube_qualityprofile.xml.key
+  rule = "xml:S1134"
+  impacts = {
+    MAINTAINABILITY = "HIGH"
+  }
+}
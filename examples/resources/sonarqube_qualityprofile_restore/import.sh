@@ -0,0 +1,2 @@
+# Import a restored quality profile using its language and name
+terraform import sonarqube_qualityprofile_restore.java java/my-java-profile
@@ -0,0 +1,3 @@
+resource "sonarqube_qualityprofile_restore" "java" {
+  backup = file("${path.module}/profiles/java.xml")
+}
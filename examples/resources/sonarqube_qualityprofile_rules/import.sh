@@ -0,0 +1,2 @@
+# Import the active rules of a quality profile using the key of the quality profile
+terraform import sonarqube_qualityprofile_rules.java AU-Tpxb--iU5OvuD2FLy
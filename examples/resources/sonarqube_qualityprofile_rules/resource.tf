@@ -0,0 +1,21 @@
+resource "sonarqube_qualityprofile" "java" {
+  name     = "my-java-profile"
+  language = "java"
+}
+
+resource "sonarqube_qualityprofile_rules" "java" {
+  key           = sonarqube_qualityprofile.java.key
+  authoritative = true
+
+  rule {
+    key      = "java:S1135"
+    severity = "INFO"
+  }
+
+  rule {
+    key = "java:S138"
+    params = {
+      max = "100"
+    }
+  }
+}
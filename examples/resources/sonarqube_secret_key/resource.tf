@@ -0,0 +1,7 @@
+resource "sonarqube_secret_key" "main" {}
+
+# Store the key in the file configured by sonar.secretKeyPath on the server
+output "sonarqube_secret_key" {
+  value     = sonarqube_secret_key.main.secret_key
+  sensitive = true
+}
@@ -0,0 +1,10 @@
+variable "smtp_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonarqube_setting" "smtp_password" {
+  key           = "email.smtp_password.secured"
+  secured_value = var.smtp_password
+  encrypted     = true
+}
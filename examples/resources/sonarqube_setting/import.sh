@@ -0,0 +1,8 @@
+# Import a global setting
+terraform import sonarqube_setting.example sonar.global.exclusions
+
+# Import a setting of a project or portfolio
+terraform import sonarqube_setting.example my-project-key/sonar.exclusions
+
+# Import a setting of a branch of a project
+terraform import sonarqube_setting.example my-project-key/my-branch-name/sonar.exclusions
@@ -0,0 +1,11 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_setting" "project_setting" {
+  component = sonarqube_project.main.project
+  key       = "sonar.exclusions"
+  values    = ["**/generated/**", "**/vendor/**"]
+}
@@ -0,0 +1,5 @@
+# Import all global settings which are not at their default value
+terraform import sonarqube_settings.global global
+
+# Import all settings which are set on a project
+terraform import sonarqube_settings.project my-project-key
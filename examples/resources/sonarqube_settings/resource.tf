@@ -0,0 +1,36 @@
+# Every global setting which is not declared here, ignored or a server, license or secured setting is reset
+resource "sonarqube_settings" "global" {
+  authoritative = true
+  ignore_keys   = ["sonar.auth.github.enabled"]
+
+  setting {
+    key   = "sonar.core.serverBaseURL"
+    value = "https://sonarqube.example.org"
+  }
+
+  setting {
+    key    = "sonar.global.exclusions"
+    values = ["**/generated/**", "**/vendor/**"]
+  }
+
+  setting {
+    key = "sonar.issue.ignore.multicriteria"
+    field_values = [
+      {
+        "ruleKey" : "foo",
+        "resourceKey" : "bar"
+      }
+    ]
+  }
+}
+
+# Every setting of the project which is not declared here is reset
+resource "sonarqube_settings" "project" {
+  component     = "my-project"
+  authoritative = true
+
+  setting {
+    key   = "sonar.scm.disabled"
+    value = "true"
+  }
+}
@@ -1,6 +1,10 @@
 resource "sonarqube_user" "remote_user" {
-  login_name = "terraform-test"
-  name       = "terraform-test"
-  email      = "terraform-test@sonarqube.com"
-  is_local   = false
+  login_name   = "terraform-test"
+  name         = "terraform-test"
+  email        = "terraform-test@sonarqube.com"
+  is_local     = false
+  scm_accounts = ["terraform-test@personal.example.org", "terraform-test-github"]
+
+  # Overrides the anonymize_user_on_delete provider setting
+  anonymize_on_destroy = true
 }
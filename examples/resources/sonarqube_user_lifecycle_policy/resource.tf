@@ -0,0 +1,7 @@
+# Deactivate the local users which did not connect during the last 180 days.
+# The plan lists the logins of the users that will be deactivated in deactivated_users.
+resource "sonarqube_user_lifecycle_policy" "license_hygiene" {
+  inactive_days  = 180
+  exclude_groups = ["sonar-administrators"]
+  exclude_logins = ["ci-service-account"]
+}
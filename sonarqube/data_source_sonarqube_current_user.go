@@ -0,0 +1,152 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CurrentUserPermissions used in CurrentUser
+type CurrentUserPermissions struct {
+	Global []string `json:"global"`
+}
+
+// CurrentUser for unmarshalling response body of api/users/current
+type CurrentUser struct {
+	IsLoggedIn       bool                   `json:"isLoggedIn"`
+	Login            string                 `json:"login"`
+	Name             string                 `json:"name"`
+	Email            string                 `json:"email"`
+	Local            bool                   `json:"local"`
+	ExternalIdentity string                 `json:"externalIdentity"`
+	ExternalProvider string                 `json:"externalProvider"`
+	Groups           []string               `json:"groups"`
+	Permissions      CurrentUserPermissions `json:"permissions"`
+}
+
+func dataSourceSonarqubeCurrentUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the Sonarqube user authenticated by the provider credentials, with its groups and global permissions.",
+		Read:        dataSourceSonarqubeCurrentUserRead,
+		Schema: map[string]*schema.Schema{
+			"login": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The login name of the user.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the user.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The email of the user.",
+			},
+			"is_local": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is local.",
+			},
+			"external_identity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identity of the user in the external authentication provider.",
+			},
+			"external_provider": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The external authentication provider of the user.",
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The groups the user is a member of.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"global_permissions": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The global permissions of the user, such as `admin`, `gateadmin`, `profileadmin`, `provisioning` or `scan`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"is_admin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has the global `admin` permission.",
+			},
+			"using_token": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the provider authenticates with a token rather than a password.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeCurrentUserRead(d *schema.ResourceData, m interface{}) error {
+	currentUser, err := readCurrentUserFromApi(m.(*ProviderConfiguration).httpClient, m.(*ProviderConfiguration).sonarQubeURL)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeCurrentUserRead: Failed to read current user: %+v", err)
+	}
+
+	// Tokens are sent as the user name, with an empty password
+	password, _ := m.(*ProviderConfiguration).sonarQubeURL.User.Password()
+
+	d.SetId(fmt.Sprintf("%d", schema.HashString(currentUser.Login)))
+	errs := []error{}
+	errs = append(errs, d.Set("login", currentUser.Login))
+	errs = append(errs, d.Set("name", currentUser.Name))
+	errs = append(errs, d.Set("email", currentUser.Email))
+	errs = append(errs, d.Set("is_local", currentUser.Local))
+	errs = append(errs, d.Set("external_identity", currentUser.ExternalIdentity))
+	errs = append(errs, d.Set("external_provider", currentUser.ExternalProvider))
+	errs = append(errs, d.Set("groups", currentUser.Groups))
+	errs = append(errs, d.Set("global_permissions", currentUser.Permissions.Global))
+	errs = append(errs, d.Set("is_admin", slices.Contains(currentUser.Permissions.Global, "admin")))
+	errs = append(errs, d.Set("using_token", password == ""))
+	return errors.Join(errs...)
+}
+
+// readCurrentUserFromApi returns the user authenticated by the credentials of sonarQubeURL.
+// It takes the client and url rather than the provider configuration, so that it can be used while configuring the provider.
+func readCurrentUserFromApi(client *retryablehttp.Client, sonarQubeURL url.URL) (*CurrentUser, error) {
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/current"
+
+	resp, err := httpRequestHelper(
+		client,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCurrentUserFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	currentUser := CurrentUser{}
+	err = json.NewDecoder(resp.Body).Decode(&currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("readCurrentUserFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	// api/users/current answers anonymous requests as well, invalid credentials are reported this way
+	if !currentUser.IsLoggedIn {
+		return nil, fmt.Errorf("readCurrentUserFromApi: the provider credentials are not valid")
+	}
+	return &currentUser, nil
+}
@@ -0,0 +1,34 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeCurrentUserDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_current_user" "%[1]s" {}`, rnd)
+}
+
+func TestAccSonarqubeCurrentUserDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_current_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeCurrentUserDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "login"),
+					resource.TestCheckResourceAttr(name, "is_admin", "true"),
+					resource.TestCheckTypeSetElemAttr(name, "global_permissions.*", "admin"),
+					resource.TestCheckTypeSetElemAttr(name, "groups.*", "sonar-administrators"),
+				),
+			},
+		},
+	})
+}
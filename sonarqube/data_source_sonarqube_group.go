@@ -19,6 +19,11 @@ func dataSourceSonarqubeGroup() *schema.Resource {
 				Computed:    true,
 				Description: "The group description.",
 			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the group is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning).",
+			},
 		},
 	}
 }
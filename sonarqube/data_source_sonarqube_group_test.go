@@ -32,6 +32,7 @@ func TestAccSonarqubeGroupDataSource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeGroupDataSource"),
 					resource.TestCheckResourceAttr(name, "description", "Terraform Test Group Data-source"),
+					resource.TestCheckResourceAttr(name, "managed", "false"),
 				),
 			},
 		},
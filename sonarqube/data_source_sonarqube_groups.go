@@ -36,6 +36,11 @@ func dataSourceSonarqubeGroups() *schema.Resource {
 							Computed:    true,
 							Description: "The group description.",
 						},
+						"managed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the group is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning).",
+						},
 					},
 				},
 				Description: "The list of groups.",
@@ -101,6 +106,7 @@ func flattenReadGroupsResponse(groups []Group) []interface{} {
 		values := map[string]interface{}{
 			"name":        group.Name,
 			"description": group.Description,
+			"managed":     group.IsManaged,
 		}
 
 		groupsList = append(groupsList, values)
@@ -0,0 +1,117 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeNewCodePeriods() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the effective new code period of every branch of a Sonarqube project",
+		Read:        dataSourceSonarqubeNewCodePeriodsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"new_code_periods": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branch": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the branch.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the new code period.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value of the new code period.",
+						},
+						"effective_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value effectively used by the new code period, e.g. the date of the analysis used as baseline.",
+						},
+						"inherited": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the new code period is inherited from the project or the global setting.",
+						},
+					},
+				},
+				Description: "The new code period of every branch of the project.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeNewCodePeriodsRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("project").(string))))
+
+	newCodePeriodsReadResponse, err := readNewCodePeriodsFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("new_code_periods", flattenReadNewCodePeriodsResponse(newCodePeriodsReadResponse.NewCodePeriods))
+}
+
+func readNewCodePeriodsFromApi(d *schema.ResourceData, m interface{}) (*GetNewCodePeriods, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/list"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{d.Get("project").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readNewCodePeriodsFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	newCodePeriodsReadResponse := GetNewCodePeriods{}
+	err = json.NewDecoder(resp.Body).Decode(&newCodePeriodsReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readNewCodePeriodsFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &newCodePeriodsReadResponse, nil
+}
+
+func flattenReadNewCodePeriodsResponse(newCodePeriods []NewCodePeriod) []interface{} {
+	newCodePeriodsList := []interface{}{}
+
+	for _, newCodePeriod := range newCodePeriods {
+		values := map[string]interface{}{
+			"branch":          newCodePeriod.Branch,
+			"type":            newCodePeriod.Type,
+			"value":           newCodePeriod.Value,
+			"effective_value": newCodePeriod.EffectiveValue,
+			"inherited":       newCodePeriod.Inherited,
+		}
+
+		newCodePeriodsList = append(newCodePeriodsList, values)
+	}
+
+	return newCodePeriodsList
+}
@@ -0,0 +1,50 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeNewCodePeriodsDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_new_code_periods" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+			type    = "NUMBER_OF_DAYS"
+			value   = "7"
+		}
+
+		data "sonarqube_new_code_periods" "%[1]s" {
+			project    = sonarqube_project.%[1]s.project
+			depends_on = [sonarqube_new_code_periods.%[1]s]
+		}`, rnd)
+}
+
+func TestAccSonarqubeNewCodePeriodsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_new_code_periods." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeNewCodePeriodsDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", rnd),
+					resource.TestCheckResourceAttr(name, "new_code_periods.#", "1"),
+					resource.TestCheckResourceAttr(name, "new_code_periods.0.type", "NUMBER_OF_DAYS"),
+					resource.TestCheckResourceAttr(name, "new_code_periods.0.value", "7"),
+					resource.TestCheckResourceAttr(name, "new_code_periods.0.inherited", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,176 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProjectQualityGateStatusCondition used in ProjectQualityGateStatus
+type ProjectQualityGateStatusCondition struct {
+	Status         string `json:"status"`
+	MetricKey      string `json:"metricKey"`
+	Comparator     string `json:"comparator"`
+	ErrorThreshold string `json:"errorThreshold"`
+	ActualValue    string `json:"actualValue"`
+}
+
+// ProjectQualityGateStatus used in GetProjectQualityGateStatus
+type ProjectQualityGateStatus struct {
+	Status            string                              `json:"status"`
+	Conditions        []ProjectQualityGateStatusCondition `json:"conditions"`
+	IgnoredConditions bool                                `json:"ignoredConditions"`
+	CaycStatus        string                              `json:"caycStatus"`
+}
+
+// GetProjectQualityGateStatus for unmarshalling response body of api/qualitygates/project_status
+type GetProjectQualityGateStatus struct {
+	ProjectStatus ProjectQualityGateStatus `json:"projectStatus"`
+}
+
+func dataSourceSonarqubeProjectQualityGateStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the Quality Gate status of the latest analysis of a Sonarqube project, branch or pull request, or of a specific analysis",
+		Read:        dataSourceSonarqubeProjectQualityGateStatusRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"pull_request", "analysis_id"},
+				Description:   "The name of the branch. Defaults to the main branch.",
+			},
+			"pull_request": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"branch", "analysis_id"},
+				Description:   "The id of the pull request.",
+			},
+			"analysis_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"branch", "pull_request"},
+				Description:   "The id of a specific analysis of the project.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the Quality Gate. One of `OK`, `WARN`, `ERROR` or `NONE`.",
+			},
+			"cayc_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the Quality Gate used by the analysis is compliant with the \"Clean as You Code\" methodology. One of `compliant`, `non-compliant` or `over-compliant`. Empty on versions of SonarQube that do not report it.",
+			},
+			"ignored_conditions": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether some conditions were ignored, e.g. because too few lines were changed.",
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceQualityGateConditionSchema(map[string]*schema.Schema{
+						"actual_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value of the metric in the analysis.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the condition. One of `OK`, `WARN`, `ERROR` or `NONE`.",
+						},
+					}),
+				},
+				Description: "List of Quality Gate conditions and their status.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectQualityGateStatusRead(d *schema.ResourceData, m interface{}) error {
+	search := fmt.Sprintf("%s/%s/%s/%s", d.Get("project").(string), d.Get("branch").(string), d.Get("pull_request").(string), d.Get("analysis_id").(string))
+	d.SetId(fmt.Sprintf("%d", schema.HashString(search)))
+
+	statusReadResponse, err := readProjectQualityGateStatusFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("status", statusReadResponse.ProjectStatus.Status))
+	errs = append(errs, d.Set("ignored_conditions", statusReadResponse.ProjectStatus.IgnoredConditions))
+	errs = append(errs, d.Set("cayc_status", statusReadResponse.ProjectStatus.CaycStatus))
+	errs = append(errs, d.Set("condition", flattenReadProjectQualityGateStatusConditionsResponse(statusReadResponse.ProjectStatus.Conditions)))
+	return errors.Join(errs...)
+}
+
+func readProjectQualityGateStatusFromApi(d *schema.ResourceData, m interface{}) (*GetProjectQualityGateStatus, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/project_status"
+
+	// The API accepts either an analysis or a project, an analysis already belongs to a single project
+	rawQuery := url.Values{}
+	if analysisId, ok := d.GetOk("analysis_id"); ok {
+		rawQuery.Add("analysisId", analysisId.(string))
+	} else {
+		rawQuery.Add("projectKey", d.Get("project").(string))
+		if branch, ok := d.GetOk("branch"); ok {
+			rawQuery.Add("branch", branch.(string))
+		}
+		if pullRequest, ok := d.GetOk("pull_request"); ok {
+			rawQuery.Add("pullRequest", pullRequest.(string))
+		}
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectQualityGateStatusFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	statusReadResponse := GetProjectQualityGateStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&statusReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectQualityGateStatusFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &statusReadResponse, nil
+}
+
+func flattenReadProjectQualityGateStatusConditionsResponse(conditions []ProjectQualityGateStatusCondition) []interface{} {
+	conditionsList := []interface{}{}
+
+	for _, condition := range conditions {
+		values := map[string]interface{}{
+			"metric":       condition.MetricKey,
+			"op":           condition.Comparator,
+			"threshold":    condition.ErrorThreshold,
+			"actual_value": condition.ActualValue,
+			"status":       condition.Status,
+		}
+
+		conditionsList = append(conditionsList, values)
+	}
+
+	return conditionsList
+}
@@ -0,0 +1,42 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectQualityGateStatusDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		data "sonarqube_project_quality_gate_status" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+		}`, rnd)
+}
+
+func TestAccSonarqubeProjectQualityGateStatusDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_project_quality_gate_status." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// A project that was never analyzed has no quality gate status
+				Config: testAccSonarqubeProjectQualityGateStatusDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", rnd),
+					resource.TestCheckResourceAttr(name, "status", "NONE"),
+					resource.TestCheckResourceAttr(name, "condition.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -24,28 +24,27 @@ func dataSourceSonarqubeQualityGate() *schema.Resource {
 				Computed:    true,
 				Description: "Quality Gate default.",
 			},
+			"cayc_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the Quality Gate is compliant with the \"Clean as You Code\" methodology. One of `compliant`, `non-compliant` or `over-compliant`.",
+			},
+			"ai_code_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Quality Gate qualifies for AI Code Assurance.",
+			},
 			"condition": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
+					Schema: dataSourceQualityGateConditionSchema(map[string]*schema.Schema{
 						"id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"metric": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"op": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the condition.",
 						},
-						"threshold": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
+					}),
 				},
 				Description: "List of Quality Gate conditions.",
 			},
@@ -53,7 +52,32 @@ func dataSourceSonarqubeQualityGate() *schema.Resource {
 	}
 }
 
+// dataSourceQualityGateConditionSchema returns the schema of a Quality Gate condition read by a data source, together with the given extra fields
+func dataSourceQualityGateConditionSchema(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	conditionSchema := map[string]*schema.Schema{
+		"metric": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Condition metric.",
+		},
+		"op": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Condition operator.",
+		},
+		"threshold": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Condition error threshold.",
+		},
+	}
+	for key, value := range extra {
+		conditionSchema[key] = value
+	}
+	return conditionSchema
+}
+
 func dataSourceSonarqubeQualityGateRead(d *schema.ResourceData, m interface{}) error {
 	d.SetId(d.Get("name").(string))
-	return resourceSonarqubeQualityGateRead(d, m)
+	return readQualityGateResourceData(d, m)
 }
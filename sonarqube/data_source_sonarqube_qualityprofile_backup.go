@@ -0,0 +1,47 @@
+package sonarqube
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeQualityProfileBackup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to export the XML backup of a Sonarqube quality profile, e.g. to restore it on another server with the `sonarqube_qualityprofile_restore` resource.",
+		Read:        dataSourceSonarqubeQualityProfileBackupRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the Quality Profile.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The language of the Quality Profile.",
+			},
+			"backup": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The XML backup of the Quality Profile.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileBackupRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+	language := d.Get("language").(string)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s", language, name))))
+
+	backup, found, err := readQualityProfileBackupFromApi(language, name, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileBackupRead: Failed to backup the quality profile: %+v", err)
+	}
+	if !found {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileBackupRead: quality profile '%s' does not exist for language '%s'", name, language)
+	}
+
+	return d.Set("backup", backup)
+}
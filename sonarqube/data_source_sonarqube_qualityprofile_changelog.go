@@ -0,0 +1,189 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityProfileChangelogEvent used in GetQualityProfileChangelog
+type QualityProfileChangelogEvent struct {
+	Date        string            `json:"date"`
+	AuthorLogin string            `json:"authorLogin"`
+	AuthorName  string            `json:"authorName"`
+	Action      string            `json:"action"`
+	RuleKey     string            `json:"ruleKey"`
+	RuleName    string            `json:"ruleName"`
+	Params      map[string]string `json:"params"`
+}
+
+// GetQualityProfileChangelog for unmarshalling response body of api/qualityprofiles/changelog
+type GetQualityProfileChangelog struct {
+	Events []QualityProfileChangelogEvent `json:"events"`
+	Total  int                            `json:"total"`
+	P      int                            `json:"p"`
+	PS     int                            `json:"ps"`
+}
+
+func dataSourceSonarqubeQualityProfileChangelog() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the changelog of a Sonarqube quality profile: the rules that were activated, deactivated or updated, when and by whom.",
+		Read:        dataSourceSonarqubeQualityProfileChangelogRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the Quality Profile.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The language of the Quality Profile.",
+			},
+			"since": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events from this date (inclusive). Either a date (e.g. `2024-01-31`) or a datetime (e.g. `2024-01-31T13:00:00+0100`).",
+			},
+			"to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events up to this date (exclusive). Either a date (e.g. `2024-01-31`) or a datetime (e.g. `2024-01-31T13:00:00+0100`).",
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the event.",
+						},
+						"author_login": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Login of the user who made the change.",
+						},
+						"author_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the user who made the change.",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The change: `ACTIVATED`, `DEACTIVATED` or `UPDATED`.",
+						},
+						"rule_key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule key.",
+						},
+						"rule_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule name.",
+						},
+						"params": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The severity and parameters set by the change.",
+						},
+					},
+				},
+				Description: "The events of the changelog, most recent first.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileChangelogRead(d *schema.ResourceData, m interface{}) error {
+	search := fmt.Sprintf("%s/%s/%s/%s", d.Get("language").(string), d.Get("name").(string), d.Get("since").(string), d.Get("to").(string))
+	d.SetId(fmt.Sprintf("%d", schema.HashString(search)))
+
+	events, err := readQualityProfileChangelogFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("events", flattenQualityProfileChangelogEvents(events))
+}
+
+func readQualityProfileChangelogFromApi(d *schema.ResourceData, m interface{}) ([]QualityProfileChangelogEvent, error) {
+	page := 1
+	pageSize := 500
+	events := []QualityProfileChangelogEvent{}
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/changelog"
+
+		rawQuery := url.Values{
+			"language":       []string{d.Get("language").(string)},
+			"qualityProfile": []string{d.Get("name").(string)},
+			"p":              []string{strconv.Itoa(page)},
+			"ps":             []string{strconv.Itoa(pageSize)},
+		}
+		if since, ok := d.GetOk("since"); ok {
+			rawQuery.Add("since", since.(string))
+		}
+		if to, ok := d.GetOk("to"); ok {
+			rawQuery.Add("to", to.(string))
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readQualityProfileChangelogFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		changelogResponse := GetQualityProfileChangelog{}
+		if err := json.NewDecoder(resp.Body).Decode(&changelogResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readQualityProfileChangelogFromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		events = append(events, changelogResponse.Events...)
+
+		if len(events) >= changelogResponse.Total || len(changelogResponse.Events) == 0 {
+			break
+		}
+		page++
+	}
+
+	return events, nil
+}
+
+func flattenQualityProfileChangelogEvents(events []QualityProfileChangelogEvent) []interface{} {
+	eventList := []interface{}{}
+
+	for _, event := range events {
+		values := map[string]interface{}{
+			"date":         event.Date,
+			"author_login": event.AuthorLogin,
+			"author_name":  event.AuthorName,
+			"action":       event.Action,
+			"rule_key":     event.RuleKey,
+			"rule_name":    event.RuleName,
+			"params":       event.Params,
+		}
+
+		eventList = append(eventList, values)
+	}
+
+	return eventList
+}
@@ -0,0 +1,213 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CompareQualityProfilesRule used in CompareQualityProfiles
+type CompareQualityProfilesRule struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// CompareQualityProfilesActivation used in CompareQualityProfilesModifiedRule
+type CompareQualityProfilesActivation struct {
+	Severity string            `json:"severity"`
+	Params   map[string]string `json:"params"`
+}
+
+// CompareQualityProfilesModifiedRule used in CompareQualityProfiles
+type CompareQualityProfilesModifiedRule struct {
+	Key   string                           `json:"key"`
+	Name  string                           `json:"name"`
+	Left  CompareQualityProfilesActivation `json:"left"`
+	Right CompareQualityProfilesActivation `json:"right"`
+}
+
+// CompareQualityProfiles for unmarshalling response body of api/qualityprofiles/compare
+type CompareQualityProfiles struct {
+	InLeft   []CompareQualityProfilesRule         `json:"inLeft"`
+	InRight  []CompareQualityProfilesRule         `json:"inRight"`
+	Modified []CompareQualityProfilesModifiedRule `json:"modified"`
+	Same     []CompareQualityProfilesRule         `json:"same"`
+}
+
+func dataSourceSonarqubeQualityProfileCompare() *schema.Resource {
+	compareRuleSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rule key.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rule name.",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to compare the active rules of two Sonarqube quality profiles.",
+		Read:        dataSourceSonarqubeQualityProfileCompareRead,
+		Schema: map[string]*schema.Schema{
+			"left_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key of the left Quality Profile. Can be obtained through api/qualityprofiles/search.",
+			},
+			"right_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key of the right Quality Profile. Can be obtained through api/qualityprofiles/search.",
+			},
+			"in_left": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        compareRuleSchema,
+				Description: "Rules that are only active in the left Quality Profile.",
+			},
+			"in_right": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        compareRuleSchema,
+				Description: "Rules that are only active in the right Quality Profile.",
+			},
+			"modified": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule key.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule name.",
+						},
+						"left_severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Severity of the rule in the left Quality Profile.",
+						},
+						"right_severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Severity of the rule in the right Quality Profile.",
+						},
+						"left_params": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Parameters of the rule in the left Quality Profile.",
+						},
+						"right_params": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Parameters of the rule in the right Quality Profile.",
+						},
+					},
+				},
+				Description: "Rules that are active in both Quality Profiles, with a different severity or parameters.",
+			},
+			"same": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        compareRuleSchema,
+				Description: "Rules that are active in both Quality Profiles, with the same severity and parameters.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileCompareRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s", d.Get("left_key").(string), d.Get("right_key").(string)))))
+
+	compareResponse, err := readQualityProfileCompareFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("in_left", flattenCompareQualityProfilesRules(compareResponse.InLeft)))
+	errs = append(errs, d.Set("in_right", flattenCompareQualityProfilesRules(compareResponse.InRight)))
+	errs = append(errs, d.Set("modified", flattenCompareQualityProfilesModifiedRules(compareResponse.Modified)))
+	errs = append(errs, d.Set("same", flattenCompareQualityProfilesRules(compareResponse.Same)))
+	return errors.Join(errs...)
+}
+
+func readQualityProfileCompareFromApi(d *schema.ResourceData, m interface{}) (*CompareQualityProfiles, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/compare"
+	sonarQubeURL.RawQuery = url.Values{
+		"leftKey":  []string{d.Get("left_key").(string)},
+		"rightKey": []string{d.Get("right_key").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readQualityProfileCompareFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	compareResponse := CompareQualityProfiles{}
+	err = json.NewDecoder(resp.Body).Decode(&compareResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readQualityProfileCompareFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &compareResponse, nil
+}
+
+func flattenCompareQualityProfilesRules(rules []CompareQualityProfilesRule) []interface{} {
+	ruleList := []interface{}{}
+
+	for _, rule := range rules {
+		values := map[string]interface{}{
+			"key":  rule.Key,
+			"name": rule.Name,
+		}
+
+		ruleList = append(ruleList, values)
+	}
+
+	return ruleList
+}
+
+func flattenCompareQualityProfilesModifiedRules(rules []CompareQualityProfilesModifiedRule) []interface{} {
+	ruleList := []interface{}{}
+
+	for _, rule := range rules {
+		values := map[string]interface{}{
+			"key":            rule.Key,
+			"name":           rule.Name,
+			"left_severity":  rule.Left.Severity,
+			"right_severity": rule.Right.Severity,
+			"left_params":    rule.Left.Params,
+			"right_params":   rule.Right.Params,
+		}
+
+		ruleList = append(ruleList, values)
+	}
+
+	return ruleList
+}
@@ -0,0 +1,80 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileCompareDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_left" {
+			name     = "%[1]s-left"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_right" {
+			name     = "%[1]s-right"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s_left" {
+			key = sonarqube_qualityprofile.%[1]s_left.key
+
+			rule {
+				key      = "java:S1135"
+				severity = "INFO"
+			}
+			rule {
+				key = "java:S138"
+				params = {
+					max = "50"
+				}
+			}
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s_right" {
+			key = sonarqube_qualityprofile.%[1]s_right.key
+
+			rule {
+				key = "java:S138"
+				params = {
+					max = "100"
+				}
+			}
+		}
+
+		data "sonarqube_qualityprofile_compare" "%[1]s" {
+			left_key  = sonarqube_qualityprofile.%[1]s_left.key
+			right_key = sonarqube_qualityprofile.%[1]s_right.key
+			depends_on = [
+				sonarqube_qualityprofile_rules.%[1]s_left,
+				sonarqube_qualityprofile_rules.%[1]s_right,
+			]
+		}`, rnd)
+}
+
+func TestAccSonarqubeQualityProfileCompareDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_compare." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileCompareDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "in_left.#", "1"),
+					resource.TestCheckResourceAttr(name, "in_left.0.key", "java:S1135"),
+					resource.TestCheckResourceAttr(name, "in_right.#", "0"),
+					resource.TestCheckResourceAttr(name, "modified.#", "1"),
+					resource.TestCheckResourceAttr(name, "modified.0.key", "java:S138"),
+					resource.TestCheckResourceAttr(name, "modified.0.left_params.max", "50"),
+					resource.TestCheckResourceAttr(name, "modified.0.right_params.max", "100"),
+				),
+			},
+		},
+	})
+}
@@ -44,6 +44,24 @@ func dataSourceSonarqubeRule() *schema.Resource {
 				Computed:    true,
 				Description: "Rule type",
 			},
+			"clean_code_attribute": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Clean Code attribute of the rule. Only available from SonarQube 10.2.",
+			},
+			"clean_code_attribute_category": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Category of the Clean Code attribute of the rule. Only available from SonarQube 10.2.",
+			},
+			"impacts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Impacts of the rule, as a map of software quality to severity. Only available from SonarQube 10.2.",
+			},
 		},
 	}
 }
@@ -29,6 +29,19 @@ func dataSourceSonarqubeUser() *schema.Resource {
 				Computed:    true,
 				Description: "Whether the user is local",
 			},
+			"scm_accounts": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The SCM accounts of the user",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning)",
+			},
 		},
 	}
 }
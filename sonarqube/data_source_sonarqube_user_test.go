@@ -34,6 +34,7 @@ func TestAccSonarqubeUserDataSource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "login_name", "testAccSonarqubeUserDataSource"),
 					resource.TestCheckResourceAttr(name, "email", "terraform-test-user-data-source@sonarqube.com"),
+					resource.TestCheckResourceAttr(name, "managed", "false"),
 				),
 			},
 		},
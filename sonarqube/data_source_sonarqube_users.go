@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceSonarqubeUsers() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to get Sonarqube user resources",
+		Description: "Use this data source to get Sonarqube user resources. All the users matching the filters are returned, which makes it suitable for access reviews.",
 		Read:        dataSourceSonarqubeUsersRead,
 		Schema: map[string]*schema.Schema{
 			"search": {
@@ -21,6 +25,29 @@ func dataSourceSonarqubeUsers() *schema.Resource {
 				Optional:    true,
 				Description: "Search users by login, name and email.",
 			},
+			"deactivated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return the deactivated users instead of the active ones. Defaults to `false`.",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the users which are (or are not) managed by an external provisioning system. Requires SonarQube 10.1 or later.",
+			},
+			"last_connected_after": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return the users which last connected at or after this RFC3339 date. Requires SonarQube 10.1 or later.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"last_connected_before": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return the users which last connected before this RFC3339 date, or never connected. Requires SonarQube 10.1 or later.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
 			"users": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -46,6 +73,47 @@ func dataSourceSonarqubeUsers() *schema.Resource {
 							Computed:    true,
 							Description: "Whether the user is local.",
 						},
+						"managed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning).",
+						},
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user is active.",
+						},
+						"groups": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The groups the user is a member of.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"last_connection_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The RFC3339 date of the last connection of the user to SonarQube, empty if the user never connected.",
+						},
+						"scm_accounts": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The SCM accounts of the user.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"external_identity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The identity of the user in the external authentication provider.",
+						},
+						"external_provider": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The external authentication provider of the user.",
+						},
 					},
 				},
 				Description: "The list of users.",
@@ -54,10 +122,22 @@ func dataSourceSonarqubeUsers() *schema.Resource {
 	}
 }
 
+func checkUsersSearchFiltersSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.1")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for the managed and last connection filters is %s", minimumVersion)
+	}
+	return nil
+}
+
 func dataSourceSonarqubeUsersRead(d *schema.ResourceData, m interface{}) error {
-	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("search"))))
+	rawQuery, err := getUsersSearchQuery(d, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUsersRead: %+v", err)
+	}
+	d.SetId(fmt.Sprintf("%d", schema.HashString(rawQuery.Encode())))
 
-	usersReadResponse, err := readUsersFromApi(d, m)
+	usersReadResponse, err := readUsersFromApi(rawQuery, m)
 	if err != nil {
 		return err
 	}
@@ -68,37 +148,86 @@ func dataSourceSonarqubeUsersRead(d *schema.ResourceData, m interface{}) error {
 	return errors.Join(errs...)
 }
 
-func readUsersFromApi(d *schema.ResourceData, m interface{}) (*GetUser, error) {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
-
-	RawQuery := url.Values{
-		"ps": []string{"500"},
-	}
+// getUsersSearchQuery returns the api/users/search filters matching the data source arguments
+func getUsersSearchQuery(d *schema.ResourceData, m interface{}) (url.Values, error) {
+	rawQuery := url.Values{}
 
 	if search, ok := d.GetOk("search"); ok {
-		RawQuery.Add("q", search.(string))
+		rawQuery.Add("q", search.(string))
+	}
+	if d.Get("deactivated").(bool) {
+		rawQuery.Add("deactivated", "true")
 	}
 
-	sonarQubeURL.RawQuery = RawQuery.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"readUsersFromApi",
-	)
-	if err != nil {
-		return nil, fmt.Errorf("readUsersFromApi: Failed to read Sonarqube users: %+v", err)
+	filters := map[string]string{}
+	if rawManaged := d.GetRawConfig().GetAttr("managed"); !rawManaged.IsNull() {
+		filters["managed"] = strconv.FormatBool(d.Get("managed").(bool))
+	}
+	for attribute, parameter := range map[string]string{
+		"last_connected_after":  "lastConnectedAfter",
+		"last_connected_before": "lastConnectedBefore",
+	} {
+		if value, ok := d.GetOk(attribute); ok {
+			// The api expects the offset without a colon
+			date, _ := time.Parse(time.RFC3339, value.(string))
+			filters[parameter] = date.Format("2006-01-02T15:04:05-0700")
+		}
+	}
+	if len(filters) > 0 {
+		if err := checkUsersSearchFiltersSupport(m.(*ProviderConfiguration)); err != nil {
+			return nil, err
+		}
+		for parameter, value := range filters {
+			rawQuery.Add(parameter, value)
+		}
 	}
-	defer resp.Body.Close()
 
-	// Decode response into struct
+	return rawQuery, nil
+}
+
+// readUsersFromApi returns all the users matching the filters, going through every page of api/users/search.
+// The v1 api is used on every version, as it is the only one returning the groups of the users.
+func readUsersFromApi(rawQuery url.Values, m interface{}) (*GetUser, error) {
 	usersReadResponse := GetUser{}
-	err = json.NewDecoder(resp.Body).Decode(&usersReadResponse)
-	if err != nil {
-		return nil, fmt.Errorf("readUsersFromApi: Failed to decode json into struct: %+v", err)
+	page := 1
+	pageSize := 500
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
+		pageQuery := url.Values{}
+		for key, values := range rawQuery {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("p", strconv.Itoa(page))
+		pageQuery.Set("ps", strconv.Itoa(pageSize))
+		sonarQubeURL.RawQuery = pageQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readUsersFromApi",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("readUsersFromApi: Failed to read Sonarqube users: %+v", err)
+		}
+
+		// Decode response into struct
+		pageResponse := GetUser{}
+		err = json.NewDecoder(resp.Body).Decode(&pageResponse)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("readUsersFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		usersReadResponse.Users = append(usersReadResponse.Users, pageResponse.Users...)
+		usersReadResponse.Paging = pageResponse.Paging
+		if int64(len(usersReadResponse.Users)) >= pageResponse.Paging.Total || len(pageResponse.Users) == 0 {
+			break
+		}
+		page++
 	}
 
 	return &usersReadResponse, nil
@@ -108,11 +237,23 @@ func flattenReadUsersResponse(users []User) []interface{} {
 	usersList := []interface{}{}
 
 	for _, user := range users {
+		lastConnectionDate := user.LastConnectionDate
+		if date, err := time.Parse("2006-01-02T15:04:05-0700", user.LastConnectionDate); err == nil {
+			lastConnectionDate = date.Format(time.RFC3339)
+		}
+
 		values := map[string]interface{}{
-			"login_name": user.Login,
-			"name":       user.Name,
-			"email":      user.Email,
-			"is_local":   user.IsLocal,
+			"login_name":           user.Login,
+			"name":                 user.Name,
+			"email":                user.Email,
+			"is_local":             user.IsLocal,
+			"managed":              user.IsManaged,
+			"active":               user.IsActive,
+			"groups":               user.Groups,
+			"last_connection_date": lastConnectionDate,
+			"scm_accounts":         user.ScmAccounts,
+			"external_identity":    user.ExternalIdentity,
+			"external_provider":    user.ExternalProvider,
 		}
 
 		usersList = append(usersList, values)
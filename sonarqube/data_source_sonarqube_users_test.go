@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -37,6 +38,60 @@ func TestAccSonarqubeUsersDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "users.0.name", "testAccSonarqubeUsersDataSource"),
 					resource.TestCheckResourceAttr(name, "users.0.email", "terraform-test@sonarqube.com"),
 					resource.TestCheckResourceAttr(name, "users.0.is_local", "true"),
+					resource.TestCheckResourceAttr(name, "users.0.active", "true"),
+					resource.TestCheckResourceAttr(name, "users.0.last_connection_date", ""),
+					resource.TestCheckTypeSetElemAttr(name, "users.0.groups.*", "sonar-users"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUsersDataSourceFiltersConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[1]s"
+			name       = "%[1]s"
+			email      = "%[1]s@sonarqube.com"
+			is_local   = false
+		}
+
+		data "sonarqube_users" "%[1]s_idle" {
+			search                = sonarqube_user.%[1]s.login_name
+			managed               = false
+			last_connected_before = "2100-01-01T00:00:00Z"
+		}
+
+		data "sonarqube_users" "%[1]s_recent" {
+			search               = sonarqube_user.%[1]s.login_name
+			last_connected_after = "2000-01-01T00:00:00Z"
+		}`, rnd)
+}
+
+func TestAccSonarqubeUsersDataSourceFilters(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_users." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.1")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping test - the managed and last connection filters require SonarQube 10.1 or later")
+						}
+					}
+				},
+				Config: testAccSonarqubeUsersDataSourceFiltersConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					// The user never connected, so it only matches last_connected_before
+					resource.TestCheckResourceAttr(name+"_idle", "users.#", "1"),
+					resource.TestCheckResourceAttr(name+"_idle", "users.0.login_name", rnd),
+					resource.TestCheckResourceAttr(name+"_idle", "users.0.managed", "false"),
+					resource.TestCheckResourceAttr(name+"_recent", "users.#", "0"),
 				),
 			},
 		},
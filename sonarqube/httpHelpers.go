@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-version"
 	"net/http"
 	"regexp"
 )
@@ -12,6 +13,8 @@ import (
 // ErrorResponse struct
 type ErrorResponse struct {
 	Errors []ErrorMessage `json:"errors,omitempty"`
+	// Error message returned by the v2 api
+	Message string `json:"message,omitempty"`
 }
 
 // ErrorMessage struct
@@ -28,11 +31,47 @@ type Paging struct {
 
 // helper function to make api request to sonarqube
 func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL string, expectedResponseCode int, resource string) (http.Response, error) {
+	return doHttpRequest(client, method, sonarqubeURL, http.NoBody, "", expectedResponseCode, resource)
+}
+
+// helper function to make api request to sonarqube with a request body, e.g. to upload a file
+func httpRequestHelperWithBody(client *retryablehttp.Client, method string, sonarqubeURL string, body []byte, contentType string, expectedResponseCode int, resource string) (http.Response, error) {
+	return doHttpRequest(client, method, sonarqubeURL, body, contentType, expectedResponseCode, resource)
+}
+
+// helper function to make api request to the v2 api of sonarqube, which takes a json request body.
+// PATCH requests are sent as a JSON merge patch, so only the fields in the body are updated.
+func httpRequestHelperWithJson(client *retryablehttp.Client, method string, sonarqubeURL string, body interface{}, expectedResponseCode int, resource string) (http.Response, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return http.Response{}, fmt.Errorf("failed to encode request body for resource %s: %+v", resource, err)
+	}
+
+	contentType := "application/json"
+	if method == http.MethodPatch {
+		contentType = "application/merge-patch+json"
+	}
+	return doHttpRequest(client, method, sonarqubeURL, jsonBody, contentType, expectedResponseCode, resource)
+}
+
+// useV2Api returns true when the server provides the v2 api for users, groups, group memberships and the GitHub and GitLab
+// authentication configurations. The v1 api is deprecated on those servers, but remains the only option on older ones such as 9.9 LTS.
+// SonarQube 10.4 only ships the v2 users api, the groups, group memberships and authentication configurations follow in 10.5.
+// The resources share their IDs, so they all switch to the v2 api together.
+func useV2Api(conf *ProviderConfiguration) bool {
+	minimumVersion, _ := version.NewVersion("10.5")
+	return conf.sonarQubeVersion.GreaterThanOrEqual(minimumVersion)
+}
+
+func doHttpRequest(client *retryablehttp.Client, method string, sonarqubeURL string, body interface{}, contentType string, expectedResponseCode int, resource string) (http.Response, error) {
 	// Prepare request
-	req, err := retryablehttp.NewRequest(method, sonarqubeURL, http.NoBody)
+	req, err := retryablehttp.NewRequest(method, sonarqubeURL, body)
 	if err != nil {
 		return http.Response{}, fmt.Errorf("failed to create request for resource %s: %w", resource, censorHttpError(err))
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	// Execute request
 	resp, err := client.Do(req)
@@ -53,6 +92,9 @@ func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL
 		if err != nil {
 			return *resp, fmt.Errorf("failed to decode error response json into struct for resource %s: %+v", resource, err)
 		}
+		if len(errorResponse.Errors) == 0 && errorResponse.Message != "" {
+			return *resp, fmt.Errorf("API returned an error for resource %s: %+v", resource, errorResponse.Message)
+		}
 		if len(errorResponse.Errors) == 0 {
 			return *resp, fmt.Errorf("statusCode: %v does not match expectedResponseCode for resource %s: %v. No error message found in the response body", resp.StatusCode, resource, expectedResponseCode)
 		}
@@ -1,7 +1,13 @@
 package sonarqube
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/go-retryablehttp"
 )
 
 func TestSanitizeSensitiveURLs(t *testing.T) {
@@ -116,3 +122,37 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.message
 }
+
+func TestHttpRequestHelperWithJson(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method {
+		case http.MethodPost:
+			if r.Header.Get("Content-Type") != "application/json" || string(body) != `{"name":"foo"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPatch:
+			if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Group 'foo' not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+
+	if _, err := httpRequestHelperWithJson(client, "POST", server.URL, map[string]string{"name": "foo"}, http.StatusOK, "test"); err != nil {
+		t.Errorf("httpRequestHelperWithJson() unexpected error for POST: %v", err)
+	}
+
+	_, err := httpRequestHelperWithJson(client, "PATCH", server.URL, map[string]string{"name": "foo"}, http.StatusOK, "test")
+	if err == nil || !strings.Contains(err.Error(), "Group 'foo' not found") {
+		t.Errorf("httpRequestHelperWithJson() = %v, want the error message of the v2 api", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-cleanhttp"
@@ -75,6 +76,12 @@ func Provider() *schema.Provider {
 				Description: "Allows anonymizing users on destroy. Requires Sonarqube version >= 9.7.",
 				Default:     false,
 			},
+			"require_admin": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Fails the provider configuration when the credentials lack the global admin permission, instead of failing part way through an apply. Defaults to false.",
+				Default:     false,
+			},
 		},
 		// Add the resources supported by this provider to this map.
 		ResourcesMap: map[string]*schema.Resource{
@@ -82,6 +89,7 @@ func Provider() *schema.Provider {
 			"sonarqube_azure_binding":                        resourceSonarqubeAzureBinding(),
 			"sonarqube_group":                                resourceSonarqubeGroup(),
 			"sonarqube_group_member":                         resourceSonarqubeGroupMember(),
+			"sonarqube_group_members":                        resourceSonarqubeGroupMembers(),
 			"sonarqube_permission_template":                  resourceSonarqubePermissionTemplate(),
 			"sonarqube_permissions":                          resourceSonarqubePermissions(),
 			"sonarqube_plugin":                               resourceSonarqubePlugin(),
@@ -92,20 +100,32 @@ func Provider() *schema.Provider {
 			"sonarqube_qualityprofile_project_association":   resourceSonarqubeQualityProfileProjectAssociation(),
 			"sonarqube_qualityprofile_usergroup_association": resourceSonarqubeQualityProfileUsergroupAssociation(),
 			"sonarqube_qualitygate":                          resourceSonarqubeQualityGate(),
+			"sonarqube_qualitygate_condition":                resourceSonarqubeQualityGateCondition(),
 			"sonarqube_qualitygate_project_association":      resourceSonarqubeQualityGateProjectAssociation(),
+			"sonarqube_qualitygate_projects":                 resourceSonarqubeQualityGateProjects(),
 			"sonarqube_qualitygate_usergroup_association":    resourceSonarqubeQualityGateUsergroupAssociation(),
 			"sonarqube_user":                                 resourceSonarqubeUser(),
+			"sonarqube_user_lifecycle_policy":                resourceSonarqubeUserLifecyclePolicy(),
 			"sonarqube_user_external_identity":               resourceSonarqubeUserExternalIdentity(),
 			"sonarqube_user_token":                           resourceSonarqubeUserToken(),
 			"sonarqube_webhook":                              resourceSonarqubeWebhook(),
 			"sonarqube_rule":                                 resourceSonarqubeRule(),
 			"sonarqube_setting":                              resourceSonarqubeSettings(),
+			"sonarqube_settings":                             resourceSonarqubeBulkSettings(),
+			"sonarqube_secret_key":                           resourceSonarqubeSecretKey(),
 			"sonarqube_qualityprofile_activate_rule":         resourceSonarqubeQualityProfileRule(),
 			"sonarqube_qualityprofile_deactivate_rule":       resourceSonarqubeQualityProfileDeactivateRule(),
+			"sonarqube_qualityprofile_rules":                 resourceSonarqubeQualityProfileRules(),
+			"sonarqube_qualityprofile_restore":               resourceSonarqubeQualityProfileRestore(),
 			"sonarqube_alm_github":                           resourceSonarqubeAlmGithub(),
 			"sonarqube_github_binding":                       resourceSonarqubeGithubBinding(),
+			"sonarqube_github_permission_mapping":            resourceSonarqubeGithubPermissionMapping(),
 			"sonarqube_alm_gitlab":                           resourceSonarqubeAlmGitlab(),
 			"sonarqube_gitlab_binding":                       resourceSonarqubeGitlabBinding(),
+			"sonarqube_gitlab_synchronized_groups":           resourceSonarqubeGitlabSynchronizedGroups(),
+			"sonarqube_auth_github":                          resourceSonarqubeAuthGithub(),
+			"sonarqube_auth_gitlab":                          resourceSonarqubeAuthGitlab(),
+			"sonarqube_auth_saml":                            resourceSonarqubeAuthSaml(),
 			"sonarqube_alm_bitbucket":                        resourceSonarqubeAlmBitbucket(),
 			"sonarqube_bitbucket_binding":                    resourceSonarqubeBitbucketBinding(),
 			"sonarqube_new_code_periods":                     resourceSonarqubeNewCodePeriodsBinding(),
@@ -113,6 +133,7 @@ func Provider() *schema.Provider {
 		DataSourcesMap: map[string]*schema.Resource{
 			"sonarqube_user":                             dataSourceSonarqubeUser(),
 			"sonarqube_users":                            dataSourceSonarqubeUsers(),
+			"sonarqube_current_user":                     dataSourceSonarqubeCurrentUser(),
 			"sonarqube_user_tokens":                      dataSourceSonarqubeUserTokens(),
 			"sonarqube_group":                            dataSourceSonarqubeGroup(),
 			"sonarqube_groups":                           dataSourceSonarqubeGroups(),
@@ -123,15 +144,20 @@ func Provider() *schema.Provider {
 			"sonarqube_qualityprofiles":                  dataSourceSonarqubeQualityProfiles(),
 			"sonarqube_qualityprofile_active_rules":      dataSourceSonarqubeQualityProfileActiveRules(),
 			"sonarqube_qualityprofile_deactivated_rules": dataSourceSonarqubeQualityProfileDeactivatedRules(),
+			"sonarqube_qualityprofile_backup":            dataSourceSonarqubeQualityProfileBackup(),
+			"sonarqube_qualityprofile_compare":           dataSourceSonarqubeQualityProfileCompare(),
+			"sonarqube_qualityprofile_changelog":         dataSourceSonarqubeQualityProfileChangelog(),
 			"sonarqube_alm_azure":                        dataSourceSonarqubeAlmAzure(),
 			"sonarqube_alm_github":                       dataSourceSonarqubeAlmGithub(),
 			"sonarqube_alm_gitlab":                       dataSourceSonarqubeAlmGitlab(),
 			"sonarqube_alm_bitbucket":                    dataSourceSonarqubeAlmBitbucket(),
 			"sonarqube_qualitygate":                      dataSourceSonarqubeQualityGate(),
 			"sonarqube_qualitygates":                     dataSourceSonarqubeQualityGates(),
+			"sonarqube_project_quality_gate_status":      dataSourceSonarqubeProjectQualityGateStatus(),
 			"sonarqube_rule":                             dataSourceSonarqubeRule(),
 			"sonarqube_languages":                        dataSourceSonarqubeLanguages(),
 			"sonarqube_permission_templates":             dataSourceSonarqubePermissionTemplates(),
+			"sonarqube_new_code_periods":                 dataSourceSonarqubeNewCodePeriods(),
 		},
 		ConfigureFunc: configureProvider,
 	}
@@ -208,6 +234,16 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		return nil, fmt.Errorf("unsupported version of sonarqube. Minimum supported version is %+v. Running version is %+v", minimumVersion, installedVersion)
 	}
 
+	if d.Get("require_admin").(bool) {
+		currentUser, err := readCurrentUserFromApi(client, sonarQubeURL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check the permissions of the provider credentials: %+v", err)
+		}
+		if !slices.Contains(currentUser.Permissions.Global, "admin") {
+			return nil, fmt.Errorf("the provider credentials of user %s lack the global admin permission required by require_admin", currentUser.Login)
+		}
+	}
+
 	// Anonymizing users is supported since version 9.7. For older releases we reset it to false:
 	minimumVersionForAnonymize, _ := version.NewVersion("9.7")
 	anonymizeUsers := d.Get("anonymize_user_on_delete").(bool) && parsedInstalledVersion.GreaterThanOrEqual(minimumVersionForAnonymize)
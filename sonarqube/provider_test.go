@@ -1,7 +1,10 @@
 package sonarqube
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +35,90 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestConfigureProviderRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		expectError bool
+	}{
+		{
+			name:        "credentials with admin permission",
+			permissions: `["admin", "scan"]`,
+			expectError: false,
+		},
+		{
+			name:        "credentials without admin permission",
+			permissions: `["scan"]`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := testStubServer(t, map[string]http.HandlerFunc{
+				"/api/users/current": testStubResponse(http.StatusOK, `{"isLoggedIn": true, "login": "terraform", "permissions": {"global": `+tt.permissions+`}}`),
+			})
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"host":              host,
+				"token":             "token",
+				"installed_version": "10.5",
+				"installed_edition": "Community",
+				"require_admin":     true,
+			})
+
+			_, err := configureProvider(d)
+			if tt.expectError && (err == nil || !strings.Contains(err.Error(), "lack the global admin permission")) {
+				t.Errorf("Expected a missing admin permission error, got: %v", err)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// testStubServer starts a stub SonarQube server answering each api path with its handler, and returns its URL.
+// Requests to any other path fail the test.
+func testStubServer(t *testing.T, handlers map[string]http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// testStubResponse returns a stub handler answering with the given status code and body
+func testStubResponse(statusCode int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// testProviderConfiguration configures the provider against a test server, without querying the server version
+func testProviderConfiguration(t *testing.T, host string, installedVersion string) *ProviderConfiguration {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":              host,
+		"token":             "token",
+		"installed_version": installedVersion,
+		"installed_edition": "Community",
+	})
+
+	conf, err := configureProvider(d)
+	if err != nil {
+		t.Fatalf("Failed to configure the provider: %v", err)
+	}
+	return conf.(*ProviderConfiguration)
+}
+
 func testAccPreCheck(t *testing.T) {
 	testSonarHost(t)
 	if v := os.Getenv("SONAR_TOKEN"); v == "" {
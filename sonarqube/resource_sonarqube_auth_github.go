@@ -0,0 +1,358 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ID used by the GitHub authentication resource when it is backed by settings
+const githubAuthenticationSettingsId = "github"
+
+// GithubConfiguration used in GetGithubConfigurations
+type GithubConfiguration struct {
+	ID                   string   `json:"id"`
+	Enabled              bool     `json:"enabled"`
+	ClientID             string   `json:"clientId,omitempty"`
+	ApplicationID        string   `json:"applicationId"`
+	SynchronizeGroups    bool     `json:"synchronizeGroups"`
+	ApiURL               string   `json:"apiUrl"`
+	WebURL               string   `json:"webUrl"`
+	AllowedOrganizations []string `json:"allowedOrganizations"`
+	ProvisioningType     string   `json:"provisioningType"`
+	AllowUsersToSignUp   bool     `json:"allowUsersToSignUp"`
+}
+
+// GetGithubConfigurations for unmarshalling response body of api/v2/dop-translation/github-configurations
+type GetGithubConfigurations struct {
+	GithubConfigurations []GithubConfiguration `json:"githubConfigurations"`
+	Page                 Paging                `json:"page"`
+}
+
+// Fields of the v2 api GitHub configuration, mapped to the attributes of the resource
+var githubConfigurationFields = map[string]string{
+	"enabled":              "enabled",
+	"clientId":             "client_id",
+	"clientSecret":         "client_secret",
+	"applicationId":        "application_id",
+	"privateKey":           "private_key",
+	"apiUrl":               "api_url",
+	"webUrl":               "web_url",
+	"allowedOrganizations": "allowed_organizations",
+	"allowUsersToSignUp":   "allow_users_to_sign_up",
+	"synchronizeGroups":    "synchronize_groups",
+	"provisioningType":     "provisioning_type",
+}
+
+// Global settings backing the GitHub authentication on servers without the v2 api
+var githubAuthenticationSettings = []settingAttribute{
+	{Attribute: "enabled", Key: "sonar.auth.github.enabled"},
+	{Attribute: "client_id", Key: "sonar.auth.github.clientId.secured", Required: true},
+	{Attribute: "client_secret", Key: "sonar.auth.github.clientSecret.secured"},
+	{Attribute: "application_id", Key: "sonar.auth.github.appId"},
+	{Attribute: "private_key", Key: "sonar.auth.github.privateKey.secured"},
+	{Attribute: "api_url", Key: "sonar.auth.github.apiUrl"},
+	{Attribute: "web_url", Key: "sonar.auth.github.webUrl"},
+	{Attribute: "allowed_organizations", Key: "sonar.auth.github.organizations"},
+	{Attribute: "allow_users_to_sign_up", Key: "sonar.auth.github.allowUsersToSignUp"},
+	{Attribute: "synchronize_groups", Key: "sonar.auth.github.groupsSync"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthGithub() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube GitHub Authentication resource. This can be used to configure the authentication of users with GitHub.
+
+On SonarQube 10.5 and later the configuration is managed through the v2 api, older servers are configured through the ` + "`sonar.auth.github.*`" + ` settings.
+The secrets are write-only, so changes made outside of Terraform are not detected.`,
+		Create: resourceSonarqubeAuthGithubCreate,
+		Read:   resourceSonarqubeAuthGithubRead,
+		Update: resourceSonarqubeAuthGithubUpdate,
+		Delete: resourceSonarqubeAuthGithubDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthGithubImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAuthGithubResource(d, meta.(*ProviderConfiguration))
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can authenticate with GitHub. Defaults to `true`.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Client ID of the GitHub App.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Client secret of the GitHub App.",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the GitHub App. Required when `provisioning_type` is `AUTO_PROVISIONING`.",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Private key of the GitHub App. Required when `provisioning_type` is `AUTO_PROVISIONING`.",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://api.github.com/",
+				Description: "The API url of the GitHub instance. Defaults to `https://api.github.com/`.",
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://github.com/",
+				Description: "The web url of the GitHub instance. Defaults to `https://github.com/`.",
+			},
+			"allowed_organizations": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only members of these organizations can authenticate. Required when `provisioning_type` is `AUTO_PROVISIONING`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users that do not exist yet are created when they authenticate. Defaults to `true`.",
+			},
+			"synchronize_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the GitHub team membership of the users is synchronized with SonarQube groups of the same name. Defaults to `false`.",
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "JIT",
+				Description: "How users and groups are provisioned: `JIT` when users authenticate, or `AUTO_PROVISIONING` from GitHub. `AUTO_PROVISIONING` requires SonarQube 10.5 or later. Defaults to `JIT`.",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"JIT", "AUTO_PROVISIONING"}, false),
+				),
+			},
+		},
+	}
+}
+
+// Validate the attributes which depend on each other
+func validateAuthGithubResource(d *schema.ResourceDiff, conf *ProviderConfiguration) error {
+	if d.Get("provisioning_type").(string) != "AUTO_PROVISIONING" {
+		return nil
+	}
+	if !useV2Api(conf) {
+		return fmt.Errorf("validateAuthGithubResource: provisioning_type AUTO_PROVISIONING requires SonarQube 10.5 or later")
+	}
+	if d.NewValueKnown("application_id") && d.Get("application_id").(string) == "" {
+		return fmt.Errorf("validateAuthGithubResource: application_id must be set when provisioning_type is AUTO_PROVISIONING")
+	}
+	if d.NewValueKnown("private_key") && d.Get("private_key").(string) == "" {
+		return fmt.Errorf("validateAuthGithubResource: private_key must be set when provisioning_type is AUTO_PROVISIONING")
+	}
+	if d.NewValueKnown("allowed_organizations") && d.Get("allowed_organizations").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("validateAuthGithubResource: allowed_organizations must be set when provisioning_type is AUTO_PROVISIONING")
+	}
+	return nil
+}
+
+func resourceSonarqubeAuthGithubCreate(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := setSettingAttributes(githubAuthenticationSettings, d, false, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGithubCreate: Failed to set GitHub authentication settings: %+v", err)
+		}
+		d.SetId(githubAuthenticationSettingsId)
+		return resourceSonarqubeAuthGithubRead(d, m)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-configurations"
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		expandAuthenticationConfiguration(githubConfigurationFields, d, false),
+		http.StatusOK,
+		"resourceSonarqubeAuthGithubCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGithubCreate: Failed to create GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configuration := GithubConfiguration{}
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGithubCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(configuration.ID)
+	return resourceSonarqubeAuthGithubRead(d, m)
+}
+
+func resourceSonarqubeAuthGithubRead(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		found, err := readSettingAttributes(githubAuthenticationSettings, d, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGithubRead: Failed to read GitHub authentication settings: %+v", err)
+		}
+		if !found {
+			// The settings have been reset outside of Terraform
+			d.SetId("")
+			return nil
+		}
+		return d.Set("provisioning_type", "JIT")
+	}
+
+	// Configurations created through the settings of an older server are found again after an upgrade
+	id := d.Id()
+	if id == githubAuthenticationSettingsId {
+		id = ""
+	}
+	configuration, err := readGithubConfigurationFromApi(id, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGithubRead: Failed to read GitHub configuration: %+v", err)
+	}
+	if configuration == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(configuration.ID)
+	errs := []error{}
+	errs = append(errs, d.Set("enabled", configuration.Enabled))
+	errs = append(errs, d.Set("application_id", configuration.ApplicationID))
+	errs = append(errs, d.Set("api_url", configuration.ApiURL))
+	errs = append(errs, d.Set("web_url", configuration.WebURL))
+	errs = append(errs, d.Set("allowed_organizations", configuration.AllowedOrganizations))
+	errs = append(errs, d.Set("allow_users_to_sign_up", configuration.AllowUsersToSignUp))
+	errs = append(errs, d.Set("synchronize_groups", configuration.SynchronizeGroups))
+	errs = append(errs, d.Set("provisioning_type", configuration.ProvisioningType))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeAuthGithubUpdate(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := setSettingAttributes(githubAuthenticationSettings, d, true, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGithubUpdate: Failed to set GitHub authentication settings: %+v", err)
+		}
+		return resourceSonarqubeAuthGithubRead(d, m)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-configurations/" + url.PathEscape(d.Id())
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarQubeURL.String(),
+		expandAuthenticationConfiguration(githubConfigurationFields, d, true),
+		http.StatusOK,
+		"resourceSonarqubeAuthGithubUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGithubUpdate: Failed to update GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeAuthGithubRead(d, m)
+}
+
+func resourceSonarqubeAuthGithubDelete(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := resetSettingAttributes(githubAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGithubDelete: Failed to reset GitHub authentication settings: %+v", err)
+		}
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-configurations/" + url.PathEscape(d.Id())
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeAuthGithubDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGithubDelete: Failed to delete GitHub configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeAuthGithubImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceSonarqubeAuthGithubRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeAuthGithubImport: GitHub configuration not found: %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readGithubConfigurationFromApi returns the GitHub configuration with the given id, or the first one when id is empty.
+// It returns nil if there is no matching configuration.
+func readGithubConfigurationFromApi(id string, m interface{}) (*GithubConfiguration, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-configurations"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readGithubConfigurationFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configurationsResponse := GetGithubConfigurations{}
+	err = json.NewDecoder(resp.Body).Decode(&configurationsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readGithubConfigurationFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, configuration := range configurationsResponse.GithubConfigurations {
+		if id == "" || configuration.ID == id {
+			return &configuration, nil
+		}
+	}
+	return nil, nil
+}
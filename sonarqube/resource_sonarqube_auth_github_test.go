@@ -0,0 +1,64 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_auth_github", &resource.Sweeper{
+		Name: "sonarqube_auth_github",
+		F:    testSweepSonarqubeAuthGithubSweeper,
+	})
+}
+
+func testSweepSonarqubeAuthGithubSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeAuthGithubConfig(rnd string, allowUsersToSignUp bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_github" "%[1]s" {
+			client_id              = "client-id"
+			client_secret          = "client-secret"
+			application_id         = "123456"
+			allowed_organizations  = ["my-organization"]
+			allow_users_to_sign_up = %[2]t
+		}`, rnd, allowUsersToSignUp)
+}
+
+func TestAccSonarqubeAuthGithub(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_github." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthGithubConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "application_id", "123456"),
+					resource.TestCheckResourceAttr(name, "allowed_organizations.#", "1"),
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "true"),
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthGithubConfig(rnd, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "false"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret", "private_key"},
+			},
+		},
+	})
+}
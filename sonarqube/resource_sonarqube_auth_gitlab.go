@@ -0,0 +1,284 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ID used by the GitLab authentication resource when it is backed by settings
+const gitlabAuthenticationSettingsId = "gitlab"
+
+// Fields of the v2 api GitLab configuration, mapped to the attributes of the resource
+var gitlabConfigurationFields = map[string]string{
+	"enabled":            "enabled",
+	"url":                "url",
+	"applicationId":      "application_id",
+	"secret":             "secret",
+	"allowUsersToSignUp": "allow_users_to_sign_up",
+	"synchronizeGroups":  "synchronize_groups",
+	"allowedGroups":      "allowed_groups",
+	"provisioningType":   "provisioning_type",
+	"provisioningToken":  "provisioning_token",
+}
+
+// Global settings backing the GitLab authentication on servers without the v2 api
+var gitlabAuthenticationSettings = []settingAttribute{
+	{Attribute: "enabled", Key: "sonar.auth.gitlab.enabled"},
+	{Attribute: "url", Key: "sonar.auth.gitlab.url"},
+	{Attribute: "application_id", Key: "sonar.auth.gitlab.applicationId.secured", Required: true},
+	{Attribute: "secret", Key: "sonar.auth.gitlab.secret.secured"},
+	{Attribute: "allow_users_to_sign_up", Key: "sonar.auth.gitlab.allowUsersToSignUp"},
+	{Attribute: "synchronize_groups", Key: "sonar.auth.gitlab.groupsSync"},
+	{Attribute: "allowed_groups", Key: "sonar.auth.gitlab.allowedGroups"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthGitlab() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube GitLab Authentication resource. This can be used to configure the authentication of users with GitLab.
+
+On SonarQube 10.5 and later the configuration is managed through the v2 api, older servers are configured through the ` + "`sonar.auth.gitlab.*`" + ` settings.
+The secrets are write-only, so changes made outside of Terraform are not detected.
+
+When ` + "`allowed_groups` and `synchronize_groups`" + ` are left unset, they can be managed by a ` + "`sonarqube_gitlab_synchronized_groups`" + ` resource instead.`,
+		Create: resourceSonarqubeAuthGitlabCreate,
+		Read:   resourceSonarqubeAuthGitlabRead,
+		Update: resourceSonarqubeAuthGitlabUpdate,
+		Delete: resourceSonarqubeAuthGitlabDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthGitlabImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAuthGitlabResource(d, meta.(*ProviderConfiguration))
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can authenticate with GitLab. Defaults to `true`.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://gitlab.com",
+				Description: "The url of the GitLab instance. Defaults to `https://gitlab.com`.",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Application ID of the GitLab OAuth application.",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Secret of the GitLab OAuth application.",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users that do not exist yet are created when they authenticate. Defaults to `true`.",
+			},
+			"synchronize_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the GitLab group membership of the users is synchronized with SonarQube groups of the same name.",
+			},
+			"allowed_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Only members of these GitLab groups (and their subgroups) can authenticate.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "JIT",
+				Description: "How users and groups are provisioned: `JIT` when users authenticate, or `AUTO_PROVISIONING` from GitLab. `AUTO_PROVISIONING` requires SonarQube 10.5 or later. Defaults to `JIT`.",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"JIT", "AUTO_PROVISIONING"}, false),
+				),
+			},
+			"provisioning_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Token used to provision users and groups from GitLab. Required when `provisioning_type` is `AUTO_PROVISIONING`.",
+			},
+		},
+	}
+}
+
+// Validate the attributes which depend on each other
+func validateAuthGitlabResource(d *schema.ResourceDiff, conf *ProviderConfiguration) error {
+	if d.Get("provisioning_type").(string) != "AUTO_PROVISIONING" {
+		return nil
+	}
+	if !useV2Api(conf) {
+		return fmt.Errorf("validateAuthGitlabResource: provisioning_type AUTO_PROVISIONING requires SonarQube 10.5 or later")
+	}
+	if d.NewValueKnown("provisioning_token") && d.Get("provisioning_token").(string) == "" {
+		return fmt.Errorf("validateAuthGitlabResource: provisioning_token must be set when provisioning_type is AUTO_PROVISIONING")
+	}
+	return nil
+}
+
+func resourceSonarqubeAuthGitlabCreate(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := setSettingAttributes(gitlabAuthenticationSettings, d, false, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGitlabCreate: Failed to set GitLab authentication settings: %+v", err)
+		}
+		d.SetId(gitlabAuthenticationSettingsId)
+		return resourceSonarqubeAuthGitlabRead(d, m)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/gitlab-configurations"
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		expandAuthenticationConfiguration(gitlabConfigurationFields, d, false),
+		http.StatusOK,
+		"resourceSonarqubeAuthGitlabCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGitlabCreate: Failed to create GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configuration := GitlabConfiguration{}
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGitlabCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(configuration.ID)
+	return resourceSonarqubeAuthGitlabRead(d, m)
+}
+
+func resourceSonarqubeAuthGitlabRead(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		found, err := readSettingAttributes(gitlabAuthenticationSettings, d, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGitlabRead: Failed to read GitLab authentication settings: %+v", err)
+		}
+		if !found {
+			// The settings have been reset outside of Terraform
+			d.SetId("")
+			return nil
+		}
+		return d.Set("provisioning_type", "JIT")
+	}
+
+	// Configurations created through the settings of an older server are found again after an upgrade
+	id := d.Id()
+	if id == gitlabAuthenticationSettingsId {
+		id = ""
+	}
+	configuration, err := readGitlabConfigurationFromApi(id, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGitlabRead: Failed to read GitLab configuration: %+v", err)
+	}
+	if configuration == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(configuration.ID)
+	errs := []error{}
+	errs = append(errs, d.Set("enabled", configuration.Enabled))
+	errs = append(errs, d.Set("url", configuration.URL))
+	errs = append(errs, d.Set("application_id", configuration.ApplicationID))
+	errs = append(errs, d.Set("allow_users_to_sign_up", configuration.AllowUsersToSignUp))
+	errs = append(errs, d.Set("synchronize_groups", configuration.SynchronizeGroups))
+	errs = append(errs, d.Set("allowed_groups", configuration.AllowedGroups))
+	errs = append(errs, d.Set("provisioning_type", configuration.ProvisioningType))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeAuthGitlabUpdate(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := setSettingAttributes(gitlabAuthenticationSettings, d, true, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGitlabUpdate: Failed to set GitLab authentication settings: %+v", err)
+		}
+		return resourceSonarqubeAuthGitlabRead(d, m)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/gitlab-configurations/" + url.PathEscape(d.Id())
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarQubeURL.String(),
+		expandAuthenticationConfiguration(gitlabConfigurationFields, d, true),
+		http.StatusOK,
+		"resourceSonarqubeAuthGitlabUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGitlabUpdate: Failed to update GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeAuthGitlabRead(d, m)
+}
+
+func resourceSonarqubeAuthGitlabDelete(d *schema.ResourceData, m interface{}) error {
+	if !useV2Api(m.(*ProviderConfiguration)) {
+		if err := resetSettingAttributes(gitlabAuthenticationSettings, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAuthGitlabDelete: Failed to reset GitLab authentication settings: %+v", err)
+		}
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/gitlab-configurations/" + url.PathEscape(d.Id())
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeAuthGitlabDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthGitlabDelete: Failed to delete GitLab configuration: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeAuthGitlabImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceSonarqubeAuthGitlabRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeAuthGitlabImport: GitLab configuration not found: %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,62 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_auth_gitlab", &resource.Sweeper{
+		Name: "sonarqube_auth_gitlab",
+		F:    testSweepSonarqubeAuthGitlabSweeper,
+	})
+}
+
+func testSweepSonarqubeAuthGitlabSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeAuthGitlabConfig(rnd string, url string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_gitlab" "%[1]s" {
+			url            = "%[2]s"
+			application_id = "application-id"
+			secret         = "secret"
+			allowed_groups = ["my-group"]
+		}`, rnd, url)
+}
+
+func TestAccSonarqubeAuthGitlab(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_gitlab." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthGitlabConfig(rnd, "https://gitlab.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "url", "https://gitlab.com"),
+					resource.TestCheckResourceAttr(name, "allowed_groups.#", "1"),
+					resource.TestCheckResourceAttr(name, "provisioning_type", "JIT"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthGitlabConfig(rnd, "https://gitlab.example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "url", "https://gitlab.example.org"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"application_id", "secret", "provisioning_token"},
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ID of the SAML authentication resource, there is a single SAML configuration per server
+const samlAuthenticationSettingsId = "saml"
+
+// Global settings backing the SAML authentication
+var samlAuthenticationSettings = []settingAttribute{
+	{Attribute: "enabled", Key: "sonar.auth.saml.enabled"},
+	{Attribute: "application_id", Key: "sonar.auth.saml.applicationId"},
+	{Attribute: "provider_name", Key: "sonar.auth.saml.providerName"},
+	{Attribute: "provider_id", Key: "sonar.auth.saml.providerId", Required: true},
+	{Attribute: "login_url", Key: "sonar.auth.saml.loginUrl"},
+	{Attribute: "certificate", Key: "sonar.auth.saml.certificate.secured"},
+	{Attribute: "user_login_attribute", Key: "sonar.auth.saml.user.login"},
+	{Attribute: "user_name_attribute", Key: "sonar.auth.saml.user.name"},
+	{Attribute: "user_email_attribute", Key: "sonar.auth.saml.user.email"},
+	{Attribute: "group_attribute", Key: "sonar.auth.saml.group.name"},
+	{Attribute: "sign_requests", Key: "sonar.auth.saml.signature.enabled"},
+	{Attribute: "service_provider_private_key", Key: "sonar.auth.saml.sp.privateKey.secured"},
+	{Attribute: "service_provider_certificate", Key: "sonar.auth.saml.sp.certificate.secured"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthSaml() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube SAML Authentication resource. This can be used to configure the authentication of users with a SAML identity provider.
+
+The configuration is managed through the ` + "`sonar.auth.saml.*`" + ` settings, as SonarQube has no dedicated api for it.
+The certificates and private key are write-only, so changes made outside of Terraform are not detected.`,
+		Create: resourceSonarqubeAuthSamlCreate,
+		Read:   resourceSonarqubeAuthSamlRead,
+		Update: resourceSonarqubeAuthSamlUpdate,
+		Delete: resourceSonarqubeAuthSamlDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthSamlImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAuthSamlResource(d)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can authenticate with SAML. Defaults to `true`.",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "sonarqube",
+				Description: "The identifier used on the identity provider when registering SonarQube. Defaults to `sonarqube`.",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "SAML",
+				Description: "Name of the identity provider displayed on the login page. Defaults to `SAML`.",
+			},
+			"provider_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifier of the identity provider, the entity that provides SAML authentication.",
+			},
+			"login_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SAML login url of the identity provider.",
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "X.509 certificate of the identity provider.",
+			},
+			"user_login_attribute": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SAML attribute holding the login of the user.",
+			},
+			"user_name_attribute": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SAML attribute holding the name of the user.",
+			},
+			"user_email_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SAML attribute holding the email of the user.",
+			},
+			"group_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SAML attribute holding the groups of the user. When set, the group membership of the users is synchronized.",
+			},
+			"sign_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the requests sent to the identity provider are signed. Requires `service_provider_private_key` and `service_provider_certificate`. Defaults to `false`.",
+			},
+			"service_provider_private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PKCS8 private key of SonarQube, used to sign the requests and decrypt the responses.",
+			},
+			"service_provider_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "X.509 certificate of SonarQube, matching `service_provider_private_key`.",
+			},
+		},
+	}
+}
+
+// Validate the attributes which depend on each other
+func validateAuthSamlResource(d *schema.ResourceDiff) error {
+	if !d.Get("sign_requests").(bool) {
+		return nil
+	}
+	if d.NewValueKnown("service_provider_private_key") && d.Get("service_provider_private_key").(string) == "" {
+		return fmt.Errorf("validateAuthSamlResource: service_provider_private_key must be set when sign_requests is true")
+	}
+	if d.NewValueKnown("service_provider_certificate") && d.Get("service_provider_certificate").(string) == "" {
+		return fmt.Errorf("validateAuthSamlResource: service_provider_certificate must be set when sign_requests is true")
+	}
+	return nil
+}
+
+func resourceSonarqubeAuthSamlCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(samlAuthenticationSettings, d, false, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthSamlCreate: Failed to set SAML authentication settings: %+v", err)
+	}
+	d.SetId(samlAuthenticationSettingsId)
+	return resourceSonarqubeAuthSamlRead(d, m)
+}
+
+func resourceSonarqubeAuthSamlRead(d *schema.ResourceData, m interface{}) error {
+	found, err := readSettingAttributes(samlAuthenticationSettings, d, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthSamlRead: Failed to read SAML authentication settings: %+v", err)
+	}
+	if !found {
+		// The settings have been reset outside of Terraform
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceSonarqubeAuthSamlUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(samlAuthenticationSettings, d, true, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthSamlUpdate: Failed to set SAML authentication settings: %+v", err)
+	}
+	return resourceSonarqubeAuthSamlRead(d, m)
+}
+
+func resourceSonarqubeAuthSamlDelete(d *schema.ResourceData, m interface{}) error {
+	if err := resetSettingAttributes(samlAuthenticationSettings, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeAuthSamlDelete: Failed to reset SAML authentication settings: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeAuthSamlImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != samlAuthenticationSettingsId {
+		return nil, fmt.Errorf("resourceSonarqubeAuthSamlImport: the SAML authentication must be imported with the id '%s'", samlAuthenticationSettingsId)
+	}
+	if err := resourceSonarqubeAuthSamlRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeAuthSamlImport: SAML authentication is not configured")
+	}
+	return []*schema.ResourceData{d}, nil
+}
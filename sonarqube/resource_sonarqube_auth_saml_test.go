@@ -0,0 +1,71 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_auth_saml", &resource.Sweeper{
+		Name: "sonarqube_auth_saml",
+		F:    testSweepSonarqubeAuthSamlSweeper,
+	})
+}
+
+func testSweepSonarqubeAuthSamlSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeAuthSamlConfig(rnd string, providerName string, signRequests bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_saml" "%[1]s" {
+			provider_name        = "%[2]s"
+			provider_id          = "https://idp.example.org/realms/sonarqube"
+			login_url            = "https://idp.example.org/realms/sonarqube/protocol/saml"
+			certificate          = "MIICertificate"
+			user_login_attribute = "login"
+			user_name_attribute  = "name"
+			user_email_attribute = "email"
+			sign_requests        = %[3]t
+		}`, rnd, providerName, signRequests)
+}
+
+func TestAccSonarqubeAuthSaml(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_saml." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeAuthSamlConfig(rnd, "Keycloak", true),
+				ExpectError: regexp.MustCompile("service_provider_private_key must be set when sign_requests is true"),
+			},
+			{
+				Config: testAccSonarqubeAuthSamlConfig(rnd, "Keycloak", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "saml"),
+					resource.TestCheckResourceAttr(name, "provider_name", "Keycloak"),
+					resource.TestCheckResourceAttr(name, "application_id", "sonarqube"),
+					resource.TestCheckResourceAttr(name, "user_email_attribute", "email"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthSamlConfig(rnd, "Corporate SSO", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provider_name", "Corporate SSO"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"certificate"},
+			},
+		},
+	})
+}
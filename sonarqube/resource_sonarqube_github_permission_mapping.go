@@ -0,0 +1,276 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GithubPermissionMappingPermissions used in GithubPermissionMapping
+type GithubPermissionMappingPermissions struct {
+	User                 bool `json:"user"`
+	CodeViewer           bool `json:"codeViewer"`
+	IssueAdmin           bool `json:"issueAdmin"`
+	SecurityHotspotAdmin bool `json:"securityHotspotAdmin"`
+	Admin                bool `json:"admin"`
+	Scan                 bool `json:"scan"`
+}
+
+// GithubPermissionMapping used in GetGithubPermissionMappings
+type GithubPermissionMapping struct {
+	ID          string                             `json:"id"`
+	GithubRole  string                             `json:"githubRole"`
+	IsBaseRole  bool                               `json:"isBaseRole"`
+	Permissions GithubPermissionMappingPermissions `json:"permissions"`
+}
+
+// GetGithubPermissionMappings for unmarshalling response body of api/v2/dop-translation/github-permission-mappings
+type GetGithubPermissionMappings struct {
+	PermissionMappings []GithubPermissionMapping `json:"permissionMappings"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGithubPermissionMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube GitHub Permission Mapping resource. This can be used to manage the SonarQube project permissions granted to the members of a GitHub role, when users and groups are provisioned from GitHub.
+
+The base GitHub roles (` + "`read`, `triage`, `write`, `maintain` and `admin`" + `) always exist: their permissions are updated in place, and destroying the resource only removes it from the state. Custom roles are created and deleted.`,
+		Create: resourceSonarqubeGithubPermissionMappingCreate,
+		Read:   resourceSonarqubeGithubPermissionMappingRead,
+		Update: resourceSonarqubeGithubPermissionMappingUpdate,
+		Delete: resourceSonarqubeGithubPermissionMappingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGithubPermissionMappingImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"github_role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The GitHub role: one of the base roles `read`, `triage`, `write`, `maintain`, `admin`, or the name of a custom role of the GitHub organization. Changing this forces a new resource to be created.",
+			},
+			"permissions": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The project permissions granted to the role. Possible values are: `admin`, `codeviewer`, `issueadmin`, `securityhotspotadmin`, `scan`, `user`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice([]string{"admin", "codeviewer", "issueadmin", "securityhotspotadmin", "scan", "user"}, false),
+					),
+				},
+			},
+			"is_base_role": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is one of the base GitHub roles.",
+			},
+		},
+	}
+}
+
+func checkGithubPermissionMappingSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.3")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for GitHub permission mappings is %s", minimumVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeGithubPermissionMappingCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkGithubPermissionMappingSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	githubRole := d.Get("github_role").(string)
+	mapping, err := readGithubPermissionMappingFromApi(githubRole, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubPermissionMappingCreate: Failed to read GitHub permission mappings: %+v", err)
+	}
+
+	if mapping != nil {
+		// The base roles always exist, so their permissions are updated instead
+		if err := updateGithubPermissionMapping(githubRole, d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubPermissionMappingCreate: Failed to update GitHub permission mapping: %+v", err)
+		}
+	} else {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-permission-mappings"
+
+		resp, err := httpRequestHelperWithJson(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			map[string]interface{}{
+				"githubRole":  githubRole,
+				"permissions": expandGithubPermissionMappingPermissions(d),
+			},
+			http.StatusOK,
+			"resourceSonarqubeGithubPermissionMappingCreate",
+		)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubPermissionMappingCreate: Failed to create GitHub permission mapping: %+v", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	d.SetId(githubRole)
+	return resourceSonarqubeGithubPermissionMappingRead(d, m)
+}
+
+func resourceSonarqubeGithubPermissionMappingRead(d *schema.ResourceData, m interface{}) error {
+	mapping, err := readGithubPermissionMappingFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubPermissionMappingRead: Failed to read GitHub permission mappings: %+v", err)
+	}
+	if mapping == nil {
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("github_role", mapping.GithubRole))
+	errs = append(errs, d.Set("permissions", flattenGithubPermissionMappingPermissions(mapping.Permissions)))
+	errs = append(errs, d.Set("is_base_role", mapping.IsBaseRole))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeGithubPermissionMappingUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := updateGithubPermissionMapping(d.Id(), d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubPermissionMappingUpdate: Failed to update GitHub permission mapping: %+v", err)
+	}
+	return resourceSonarqubeGithubPermissionMappingRead(d, m)
+}
+
+func resourceSonarqubeGithubPermissionMappingDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("is_base_role").(bool) {
+		// Base roles cannot be deleted, they are only removed from the state
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-permission-mappings/" + url.PathEscape(d.Id())
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeGithubPermissionMappingDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubPermissionMappingDelete: Failed to delete GitHub permission mapping: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeGithubPermissionMappingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	githubRole := d.Id()
+	if err := resourceSonarqubeGithubPermissionMappingRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGithubPermissionMappingImport: GitHub permission mapping not found: %s", githubRole)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readGithubPermissionMappingFromApi returns the permission mapping of the GitHub role, or nil if there is none
+func readGithubPermissionMappingFromApi(githubRole string, m interface{}) (*GithubPermissionMapping, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-permission-mappings"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readGithubPermissionMappingFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	mappingsResponse := GetGithubPermissionMappings{}
+	err = json.NewDecoder(resp.Body).Decode(&mappingsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readGithubPermissionMappingFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, mapping := range mappingsResponse.PermissionMappings {
+		if mapping.GithubRole == githubRole {
+			return &mapping, nil
+		}
+	}
+	return nil, nil
+}
+
+func updateGithubPermissionMapping(githubRole string, d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/github-permission-mappings/" + url.PathEscape(githubRole)
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarQubeURL.String(),
+		map[string]interface{}{
+			"permissions": expandGithubPermissionMappingPermissions(d),
+		},
+		http.StatusOK,
+		"updateGithubPermissionMapping",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// expandGithubPermissionMappingPermissions converts the declared permissions into the flags expected by the API
+func expandGithubPermissionMappingPermissions(d *schema.ResourceData) GithubPermissionMappingPermissions {
+	permissions := d.Get("permissions").(*schema.Set)
+	return GithubPermissionMappingPermissions{
+		User:                 permissions.Contains("user"),
+		CodeViewer:           permissions.Contains("codeviewer"),
+		IssueAdmin:           permissions.Contains("issueadmin"),
+		SecurityHotspotAdmin: permissions.Contains("securityhotspotadmin"),
+		Admin:                permissions.Contains("admin"),
+		Scan:                 permissions.Contains("scan"),
+	}
+}
+
+// flattenGithubPermissionMappingPermissions converts the flags returned by the API into permissions
+func flattenGithubPermissionMappingPermissions(permissions GithubPermissionMappingPermissions) []interface{} {
+	flattened := []interface{}{}
+	flags := []struct {
+		permission string
+		granted    bool
+	}{
+		{"user", permissions.User},
+		{"codeviewer", permissions.CodeViewer},
+		{"issueadmin", permissions.IssueAdmin},
+		{"securityhotspotadmin", permissions.SecurityHotspotAdmin},
+		{"admin", permissions.Admin},
+		{"scan", permissions.Scan},
+	}
+	for _, flag := range flags {
+		if flag.granted {
+			flattened = append(flattened, flag.permission)
+		}
+	}
+	return flattened
+}
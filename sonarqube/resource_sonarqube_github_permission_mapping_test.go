@@ -0,0 +1,68 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_github_permission_mapping", &resource.Sweeper{
+		Name: "sonarqube_github_permission_mapping",
+		F:    testSweepSonarqubeGithubPermissionMappingSweeper,
+	})
+}
+
+func testSweepSonarqubeGithubPermissionMappingSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGithubPermissionMappingConfig(rnd string, permissions string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_github_permission_mapping" "%[1]s" {
+			github_role = "triage"
+			permissions = %[2]s
+		}`, rnd, permissions)
+}
+
+func TestAccSonarqubeGithubPermissionMappingBaseRole(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_github_permission_mapping." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.3")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping test - GitHub permission mappings require SonarQube 10.3 or later")
+						}
+					}
+				},
+				Config: testAccSonarqubeGithubPermissionMappingConfig(rnd, `["user", "codeviewer"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "triage"),
+					resource.TestCheckResourceAttr(name, "is_base_role", "true"),
+					resource.TestCheckResourceAttr(name, "permissions.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGithubPermissionMappingConfig(rnd, `["user", "codeviewer", "issueadmin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "permissions.#", "3"),
+					resource.TestCheckTypeSetElemAttr(name, "permissions.*", "issueadmin"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,211 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GitlabConfiguration used in GetGitlabConfigurations
+type GitlabConfiguration struct {
+	ID                 string   `json:"id"`
+	Enabled            bool     `json:"enabled"`
+	ApplicationID      string   `json:"applicationId"`
+	URL                string   `json:"url"`
+	SynchronizeGroups  bool     `json:"synchronizeGroups"`
+	AllowedGroups      []string `json:"allowedGroups"`
+	ProvisioningType   string   `json:"provisioningType"`
+	AllowUsersToSignUp bool     `json:"allowUsersToSignUp"`
+}
+
+// GetGitlabConfigurations for unmarshalling response body of api/v2/dop-translation/gitlab-configurations
+type GetGitlabConfigurations struct {
+	GitlabConfigurations []GitlabConfiguration `json:"gitlabConfigurations"`
+	Page                 Paging                `json:"page"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGitlabSynchronizedGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube GitLab Synchronized Groups resource. This can be used to manage which GitLab groups are synchronized with SonarQube when users authenticate with GitLab.
+
+The GitLab authentication must already be configured on the server, for example with a ` + "`sonarqube_auth_gitlab`" + ` resource where ` + "`allowed_groups` and `synchronize_groups`" + ` are left unset. Destroying this resource disables the group synchronization and leaves the allowed groups unchanged.`,
+		Create: resourceSonarqubeGitlabSynchronizedGroupsCreate,
+		Read:   resourceSonarqubeGitlabSynchronizedGroupsRead,
+		Update: resourceSonarqubeGitlabSynchronizedGroupsUpdate,
+		Delete: resourceSonarqubeGitlabSynchronizedGroupsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGitlabSynchronizedGroupsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"allowed_groups": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The GitLab groups (and their subgroups) whose members are allowed to authenticate and whose membership is synchronized.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"synchronize_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the GitLab group membership of the users is synchronized with SonarQube groups of the same name. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func checkGitlabSynchronizedGroupsSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.5")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for GitLab synchronized groups is %s", minimumVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeGitlabSynchronizedGroupsCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkGitlabSynchronizedGroupsSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	configuration, err := readGitlabConfigurationFromApi("", m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsCreate: Failed to read GitLab configuration: %+v", err)
+	}
+	if configuration == nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsCreate: GitLab authentication is not configured")
+	}
+
+	err = updateGitlabSynchronizedGroups(configuration.ID, map[string]interface{}{
+		"synchronizeGroups": d.Get("synchronize_groups").(bool),
+		"allowedGroups":     expandGitlabAllowedGroups(d),
+	}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsCreate: Failed to update GitLab configuration: %+v", err)
+	}
+
+	d.SetId(configuration.ID)
+	return resourceSonarqubeGitlabSynchronizedGroupsRead(d, m)
+}
+
+func resourceSonarqubeGitlabSynchronizedGroupsRead(d *schema.ResourceData, m interface{}) error {
+	configuration, err := readGitlabConfigurationFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsRead: Failed to read GitLab configuration: %+v", err)
+	}
+	if configuration == nil {
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("allowed_groups", configuration.AllowedGroups))
+	errs = append(errs, d.Set("synchronize_groups", configuration.SynchronizeGroups))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeGitlabSynchronizedGroupsUpdate(d *schema.ResourceData, m interface{}) error {
+	body := map[string]interface{}{}
+	if d.HasChange("synchronize_groups") {
+		body["synchronizeGroups"] = d.Get("synchronize_groups").(bool)
+	}
+	if d.HasChange("allowed_groups") {
+		body["allowedGroups"] = expandGitlabAllowedGroups(d)
+	}
+
+	if err := updateGitlabSynchronizedGroups(d.Id(), body, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsUpdate: Failed to update GitLab configuration: %+v", err)
+	}
+	return resourceSonarqubeGitlabSynchronizedGroupsRead(d, m)
+}
+
+func resourceSonarqubeGitlabSynchronizedGroupsDelete(d *schema.ResourceData, m interface{}) error {
+	err := updateGitlabSynchronizedGroups(d.Id(), map[string]interface{}{
+		"synchronizeGroups": false,
+	}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsDelete: Failed to update GitLab configuration: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeGitlabSynchronizedGroupsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceSonarqubeGitlabSynchronizedGroupsRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGitlabSynchronizedGroupsImport: GitLab configuration not found: %s", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readGitlabConfigurationFromApi returns the GitLab configuration with the given id, or the first one when id is empty.
+// It returns nil if there is no matching configuration.
+func readGitlabConfigurationFromApi(id string, m interface{}) (*GitlabConfiguration, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/gitlab-configurations"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readGitlabConfigurationFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	configurationsResponse := GetGitlabConfigurations{}
+	err = json.NewDecoder(resp.Body).Decode(&configurationsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readGitlabConfigurationFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, configuration := range configurationsResponse.GitlabConfigurations {
+		if id == "" || configuration.ID == id {
+			return &configuration, nil
+		}
+	}
+	return nil, nil
+}
+
+func updateGitlabSynchronizedGroups(id string, body map[string]interface{}, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/gitlab-configurations/" + url.PathEscape(id)
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarQubeURL.String(),
+		body,
+		http.StatusOK,
+		"updateGitlabSynchronizedGroups",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func expandGitlabAllowedGroups(d *schema.ResourceData) []string {
+	allowedGroups := []string{}
+	for _, group := range d.Get("allowed_groups").(*schema.Set).List() {
+		allowedGroups = append(allowedGroups, group.(string))
+	}
+	return allowedGroups
+}
@@ -0,0 +1,71 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_gitlab_synchronized_groups", &resource.Sweeper{
+		Name: "sonarqube_gitlab_synchronized_groups",
+		F:    testSweepSonarqubeGitlabSynchronizedGroupsSweeper,
+	})
+}
+
+func testSweepSonarqubeGitlabSynchronizedGroupsSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGitlabSynchronizedGroupsConfig(rnd string, allowedGroups string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_gitlab_synchronized_groups" "%[1]s" {
+			allowed_groups = %[2]s
+		}`, rnd, allowedGroups)
+}
+
+func TestAccSonarqubeGitlabSynchronizedGroups(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_gitlab_synchronized_groups." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.5")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping test - GitLab synchronized groups require SonarQube 10.5 or later")
+						}
+						// The GitLab authentication has to be configured beforehand
+						configuration, err := readGitlabConfigurationFromApi("", testAccProvider.Meta())
+						if err != nil || configuration == nil {
+							t.Skip("Skipping test - GitLab authentication is not configured")
+						}
+					}
+				},
+				Config: testAccSonarqubeGitlabSynchronizedGroupsConfig(rnd, `["my-group"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "synchronize_groups", "true"),
+					resource.TestCheckResourceAttr(name, "allowed_groups.#", "1"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGitlabSynchronizedGroupsConfig(rnd, `["my-group", "my-other-group"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "allowed_groups.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "allowed_groups.*", "my-other-group"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
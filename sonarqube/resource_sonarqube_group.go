@@ -1,13 +1,16 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -31,6 +34,22 @@ type Group struct {
 	MembersCount int      `json:"membersCount,omitempty"`
 	IsDefault    bool     `json:"default,omitempty"`
 	Permissions  []string `json:"permissions,omitempty"`
+	IsManaged    bool     `json:"managed,omitempty"`
+}
+
+// GroupV2 struct, as returned by the v2 authorizations api
+type GroupV2 struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsManaged   bool   `json:"managed"`
+	IsDefault   bool   `json:"default"`
+}
+
+// GetGroupsV2 for unmarshalling response body of api/v2/authorizations/groups
+type GetGroupsV2 struct {
+	Page   Paging    `json:"page"`
+	Groups []GroupV2 `json:"groups"`
 }
 
 // Returns the resource represented by this file.
@@ -44,6 +63,12 @@ func resourceSonarqubeGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeGroupImport,
 		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateGroupNotManaged(d)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -57,11 +82,37 @@ func resourceSonarqubeGroup() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the Group.",
 			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Group is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning). Managed Groups cannot be modified or deleted from Terraform.",
+			},
 		},
 	}
 }
 
+// managedGroupError explains why a group which is managed by an external provisioning system cannot be changed
+func managedGroupError(name string, action string) error {
+	return fmt.Errorf("group '%s' is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning) and cannot be %s from Terraform: make the change in the identity provider instead", name, action)
+}
+
+// Validate that a group which is managed by an external provisioning system is not modified
+func validateGroupNotManaged(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.Get("managed").(bool) {
+		return nil
+	}
+	if d.HasChanges("name", "description") {
+		oldName, _ := d.GetChange("name")
+		return managedGroupError(oldName.(string), "modified")
+	}
+	return nil
+}
+
 func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeGroupCreateV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/create"
 	sonarQubeURL.RawQuery = url.Values{
@@ -93,6 +144,10 @@ func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeGroupReadV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
 	sonarQubeURL.RawQuery = url.Values{
@@ -133,7 +188,8 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 			// If it does, set the values of that group
 			errName := d.Set("name", value.Name)
 			errDesc := d.Set("description", value.Description)
-			if err := errors.Join(errName, errDesc); err != nil {
+			errManaged := d.Set("managed", value.IsManaged)
+			if err := errors.Join(errName, errDesc, errManaged); err != nil {
 				return err
 			}
 			readSuccess = true
@@ -152,6 +208,10 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeGroupUpdateV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/update"
 
@@ -188,6 +248,14 @@ func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("managed").(bool) {
+		return managedGroupError(d.Get("name").(string), "deleted")
+	}
+
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeGroupDeleteV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/delete"
 
@@ -222,3 +290,204 @@ func resourceSonarqubeGroupImport(d *schema.ResourceData, m interface{}) ([]*sch
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+func resourceSonarqubeGroupCreateV2(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups"
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		map[string]interface{}{
+			"name":        d.Get("name").(string),
+			"description": d.Get("description").(string),
+		},
+		http.StatusOK,
+		"resourceSonarqubeGroupCreateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groupResponse := GroupV2{}
+	err = json.NewDecoder(resp.Body).Decode(&groupResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupCreateV2: Failed to decode json into struct: %+v", err)
+	}
+	d.SetId(groupResponse.ID)
+
+	return resourceSonarqubeGroupReadV2(d, m)
+}
+
+func resourceSonarqubeGroupReadV2(d *schema.ResourceData, m interface{}) error {
+	group, err := readGroupV2ForResource(d.Id(), d.Get("name").(string), m)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		// Group not found
+		d.SetId("")
+		return nil
+	}
+
+	// Groups created or imported with the v1 api may not have their ID in the state yet
+	d.SetId(group.ID)
+	errName := d.Set("name", group.Name)
+	errDesc := d.Set("description", group.Description)
+	errManaged := d.Set("managed", group.IsManaged)
+	return errors.Join(errName, errDesc, errManaged)
+}
+
+func resourceSonarqubeGroupUpdateV2(d *schema.ResourceData, m interface{}) error {
+	oldName, newName := d.GetChange("name")
+	group, err := readGroupV2ForResource(d.Id(), oldName.(string), m)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		return fmt.Errorf("resourceSonarqubeGroupUpdateV2: Failed to find group: %+v", oldName)
+	}
+
+	body := map[string]interface{}{
+		"description": d.Get("description").(string),
+	}
+	if newName != oldName {
+		body["name"] = newName.(string)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(group.ID)
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"PATCH",
+		sonarQubeURL.String(),
+		body,
+		http.StatusOK,
+		"resourceSonarqubeGroupUpdateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeGroupReadV2(d, m)
+}
+
+func resourceSonarqubeGroupDeleteV2(d *schema.ResourceData, m interface{}) error {
+	group, err := readGroupV2ForResource(d.Id(), d.Get("name").(string), m)
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube group: %+v", err)
+	}
+	if group == nil {
+		// The group is already deleted
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(group.ID)
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeGroupDeleteV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// readGroupV2ForResource returns the group with the given ID, so that a group renamed outside of Terraform is still found.
+// Groups created or imported with the v1 api have their name or a v1 ID in the state, those are looked up by name instead.
+func readGroupV2ForResource(id string, name string, m interface{}) (*GroupV2, error) {
+	if id != "" {
+		group, err := readGroupV2ByIdFromApi(id, m)
+		if err != nil || group != nil {
+			return group, err
+		}
+	}
+	return readGroupV2FromApi(name, m)
+}
+
+// readGroupV2ByIdFromApi returns the group with the given ID, or nil if there is no such group
+func readGroupV2ByIdFromApi(id string, m interface{}) (*GroupV2, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(id)
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readGroupV2ByIdFromApi",
+	)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	group := GroupV2{}
+	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
+		return nil, fmt.Errorf("readGroupV2ByIdFromApi: Failed to decode json into struct: %+v", err)
+	}
+	return &group, nil
+}
+
+// readGroupV2FromApi returns the group with the given name, or nil if there is no such group
+func readGroupV2FromApi(name string, m interface{}) (*GroupV2, error) {
+	pageIndex := 1
+	pageSize := 500
+	found := 0
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups"
+		sonarQubeURL.RawQuery = url.Values{
+			"q":         []string{name},
+			"pageIndex": []string{strconv.Itoa(pageIndex)},
+			"pageSize":  []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readGroupV2FromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		groupsResponse := GetGroupsV2{}
+		if err := json.NewDecoder(resp.Body).Decode(&groupsResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readGroupV2FromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		// The search matches on part of the name, so look for the exact name
+		for _, group := range groupsResponse.Groups {
+			if group.Name == name {
+				return &group, nil
+			}
+		}
+
+		found += len(groupsResponse.Groups)
+		if int64(found) >= groupsResponse.Page.Total || len(groupsResponse.Groups) == 0 {
+			return nil, nil
+		}
+		pageIndex++
+	}
+}
@@ -24,13 +24,28 @@ type GetGroupMembersResponse struct {
 	Members []GroupMember `json:"users"`
 }
 
+// GroupMembershipV2 struct, as returned by the v2 authorizations api
+type GroupMembershipV2 struct {
+	ID      string `json:"id"`
+	GroupID string `json:"groupId"`
+	UserID  string `json:"userId"`
+}
+
+// GetGroupMembershipsV2 for unmarshalling response body of api/v2/authorizations/group-memberships
+type GetGroupMembershipsV2 struct {
+	Page             Paging              `json:"page"`
+	GroupMemberships []GroupMembershipV2 `json:"groupMemberships"`
+}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeGroupMember() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Group Member resource. This can be used to add or remove user to or from Sonarqube Groups.",
-		Create:      resourceSonarqubeGroupMemberCreate,
-		Read:        resourceSonarqubeGroupMemberRead,
-		Delete:      resourceSonarqubeGroupMemberDelete,
+		Description: `Provides a Sonarqube Group Member resource. This can be used to add or remove user to or from Sonarqube Groups.
+
+Members of groups which are managed by an external provisioning system (SCIM, GitHub or GitLab provisioning) are synchronized from the identity provider: an existing membership can be declared, but it is not added or removed by Terraform. When the identity provider removes the user from such a group, the next apply fails until the user is added back in the identity provider or the membership is removed from the configuration.`,
+		Create: resourceSonarqubeGroupMemberCreate,
+		Read:   resourceSonarqubeGroupMemberRead,
+		Delete: resourceSonarqubeGroupMemberDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeGroupMemberImport,
 		},
@@ -54,32 +69,28 @@ func resourceSonarqubeGroupMember() *schema.Resource {
 }
 
 func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/add_user"
-	sonarQubeURL.RawQuery = url.Values{
-		"name":  []string{d.Get("name").(string)},
-		"login": []string{d.Get("login_name").(string)},
-	}.Encode()
+	groupName := d.Get("name").(string)
+	loginName := d.Get("login_name").(string)
 
-	groupMembershipId := createGroupMembershipId(d.Get("name").(string), d.Get("login_name").(string))
+	managed, err := isManagedGroup(groupName, m)
+	if err != nil {
+		return fmt.Errorf("error adding user '%s' to Sonarqube group '%s': %w", loginName, groupName, err)
+	}
+	if managed {
+		return adoptManagedGroupMember(d, m)
+	}
+
+	groupMembershipId := createGroupMembershipId(groupName, loginName)
 
 	// We need to check if a user is already a member in advance because SQ does not report this conflict in the add_user API call:
-	exists, _ := checkGroupMemberExists(d.Get("name").(string), d.Get("login_name").(string), m)
+	exists, _ := checkGroupMemberExists(groupName, loginName, m)
 	if exists {
 		return fmt.Errorf("resourceSonarqubeGroupMemberCreate: Group membership already exists: %+v", groupMembershipId)
 	}
 
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeGroupMemberCreate",
-	)
-	if err != nil {
-		return fmt.Errorf("error adding user '%s' to Sonarqube group '%s': %w", d.Get("login_name").(string), d.Get("name").(string), err)
+	if err := addGroupMember(groupName, loginName, m); err != nil {
+		return fmt.Errorf("error adding user '%s' to Sonarqube group '%s': %w", loginName, groupName, err)
 	}
-	defer resp.Body.Close()
 
 	d.SetId(groupMembershipId)
 
@@ -87,6 +98,10 @@ func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) e
 }
 
 func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeGroupMemberReadV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
 	sonarQubeURL.RawQuery = url.Values{
@@ -129,7 +144,7 @@ func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) err
 	}
 
 	if !readSuccess {
-		// Group member not found
+		// Group member not found, also for managed groups so that a membership removed by the identity provider shows up in the plan
 		d.SetId("")
 	}
 
@@ -137,25 +152,21 @@ func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) err
 }
 
 func resourceSonarqubeGroupMemberDelete(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/remove_user"
+	groupName := d.Get("name").(string)
+	loginName := d.Get("login_name").(string)
 
-	sonarQubeURL.RawQuery = url.Values{
-		"name":  []string{d.Get("name").(string)},
-		"login": []string{d.Get("login_name").(string)},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeGroupMemberDelete",
-	)
+	managed, err := isManagedGroup(groupName, m)
 	if err != nil {
-		return fmt.Errorf("error deleting Sonarqube member '%s' from group '%s': %w", d.Get("login_name").(string), d.Get("name").(string), err)
+		return fmt.Errorf("error deleting Sonarqube member '%s' from group '%s': %w", loginName, groupName, err)
+	}
+	if managed {
+		// Members of managed groups are synchronized from the identity provider, the membership is only removed from the state
+		return nil
+	}
+
+	if err := removeGroupMember(groupName, loginName, m); err != nil {
+		return fmt.Errorf("error deleting Sonarqube member '%s' from group '%s': %w", loginName, groupName, err)
 	}
-	defer resp.Body.Close()
 
 	return nil
 }
@@ -178,6 +189,11 @@ func resourceSonarqubeGroupMemberImport(d *schema.ResourceData, m interface{}) (
 }
 
 func checkGroupMemberExists(groupName string, loginName string, m interface{}) (bool, error) {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		membership, err := readGroupMembershipV2FromApi(groupName, loginName, m)
+		return membership != nil, err
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
 	sonarQubeURL.RawQuery = url.Values{
@@ -213,6 +229,244 @@ func checkGroupMemberExists(groupName string, loginName string, m interface{}) (
 	return false, nil
 }
 
+// isManagedGroup returns true when the group is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning)
+func isManagedGroup(groupName string, m interface{}) (bool, error) {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		group, err := readGroupV2FromApi(groupName, m)
+		if err != nil || group == nil {
+			return false, err
+		}
+		return group.IsManaged, nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"ps": []string{"500"},
+		"q":  []string{groupName},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"isManagedGroup",
+	)
+	if err != nil {
+		return false, fmt.Errorf("error reading Sonarqube group '%s': %w", groupName, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groupReadResponse := GetGroup{}
+	err = json.NewDecoder(resp.Body).Decode(&groupReadResponse)
+	if err != nil {
+		return false, fmt.Errorf("isManagedGroup: Failed to decode json into struct: %w", err)
+	}
+	for _, group := range groupReadResponse.Groups {
+		if group.Name == groupName {
+			return group.IsManaged, nil
+		}
+	}
+	return false, nil
+}
+
+// adoptManagedGroupMember brings an existing member of a managed group under Terraform, since members of managed groups
+// are synchronized from the identity provider and cannot be added from Terraform.
+func adoptManagedGroupMember(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("name").(string)
+	loginName := d.Get("login_name").(string)
+
+	exists, err := checkGroupMemberExists(groupName, loginName, m)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("group '%s' is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning) and user '%s' cannot be added to it from Terraform: add the user to the group in the identity provider instead", groupName, loginName)
+	}
+
+	d.SetId(createGroupMembershipId(groupName, loginName))
+	return nil
+}
+
+// addGroupMember adds the user to the group, through the v2 api when the server provides it
+func addGroupMember(groupName string, loginName string, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		group, err := readGroupV2FromApi(groupName, m)
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			return fmt.Errorf("addGroupMember: Failed to find group: %+v", groupName)
+		}
+		user, err := readUserV2FromApi(loginName, m)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("addGroupMember: Failed to find user: %+v", loginName)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/group-memberships"
+
+		resp, err := httpRequestHelperWithJson(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			map[string]interface{}{
+				"groupId": group.ID,
+				"userId":  user.ID,
+			},
+			http.StatusOK,
+			"addGroupMember",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/add_user"
+	sonarQubeURL.RawQuery = url.Values{
+		"name":  []string{groupName},
+		"login": []string{loginName},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"addGroupMember",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// removeGroupMember removes the user from the group, through the v2 api when the server provides it
+func removeGroupMember(groupName string, loginName string, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		membership, err := readGroupMembershipV2FromApi(groupName, loginName, m)
+		if err != nil {
+			return err
+		}
+		if membership == nil {
+			// The user is no longer a member of the group
+			return nil
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/group-memberships/" + url.PathEscape(membership.ID)
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"DELETE",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"removeGroupMember",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/remove_user"
+	sonarQubeURL.RawQuery = url.Values{
+		"name":  []string{groupName},
+		"login": []string{loginName},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"removeGroupMember",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 func createGroupMembershipId(groupName string, loginName string) string {
 	return groupName + "[" + loginName + "]"
 }
+
+func resourceSonarqubeGroupMemberReadV2(d *schema.ResourceData, m interface{}) error {
+	membership, err := readGroupMembershipV2FromApi(d.Get("name").(string), d.Get("login_name").(string), m)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube members of group '%s': %w", d.Get("name").(string), err)
+	}
+	if membership == nil {
+		// Group member not found, also for managed groups so that a membership removed by the identity provider shows up in the plan
+		d.SetId("")
+	}
+
+	d.SetId(createGroupMembershipId(d.Get("name").(string), d.Get("login_name").(string)))
+	return nil
+}
+
+// readGroupMembershipV2FromApi returns the membership of the user in the group, or nil if the group, the user or the membership does not exist
+func readGroupMembershipV2FromApi(groupName string, loginName string, m interface{}) (*GroupMembershipV2, error) {
+	group, err := readGroupV2FromApi(groupName, m)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	user, err := readUserV2FromApi(loginName, m)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return findGroupMembershipV2(group.ID, user.ID, m)
+}
+
+// findGroupMembershipV2 returns the membership of the user in the group, or nil if the user is not a member of the group
+func findGroupMembershipV2(groupId string, userId string, m interface{}) (*GroupMembershipV2, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/group-memberships"
+	sonarQubeURL.RawQuery = url.Values{
+		"groupId":   []string{groupId},
+		"userId":    []string{userId},
+		"pageIndex": []string{"1"},
+		"pageSize":  []string{"1"},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findGroupMembershipV2",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	membershipsResponse := GetGroupMembershipsV2{}
+	err = json.NewDecoder(resp.Body).Decode(&membershipsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("findGroupMembershipV2: Failed to decode json into struct: %+v", err)
+	}
+
+	if len(membershipsResponse.GroupMemberships) == 0 {
+		return nil, nil
+	}
+	return &membershipsResponse.GroupMemberships[0], nil
+}
@@ -0,0 +1,200 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Group Members resource. This can be used to manage all the members of a Sonarqube Group at once.
+
+This resource is authoritative: members of the Group which are not listed in ` + "`login_names`" + ` are removed, and members added outside of Terraform show up as drift in the plan. On destroy, only the listed members are removed from the Group.
+The members are read with a single (paginated) search, and only the differences are applied.
+This resource should not be combined with ` + "`sonarqube_group_member`" + ` resources for the same Group, and cannot be used with Groups managed by an external provisioning system (SCIM, GitHub or GitLab provisioning).`,
+		Create: resourceSonarqubeGroupMembersCreate,
+		Read:   resourceSonarqubeGroupMembersRead,
+		Update: resourceSonarqubeGroupMembersUpdate,
+		Delete: resourceSonarqubeGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGroupMembersImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Group. Changing this forces a new resource to be created.",
+			},
+			"login_names": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The `login_name` of every User that should be a member of the Group.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGroupMembersCreate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeGroupMembers(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersCreate: Failed to synchronize members: %+v", err)
+	}
+
+	d.SetId(d.Get("name").(string))
+	return resourceSonarqubeGroupMembersRead(d, m)
+}
+
+func resourceSonarqubeGroupMembersRead(d *schema.ResourceData, m interface{}) error {
+	members, err := readGroupMemberLoginsFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersRead: Failed to read the members of the group: %+v", err)
+	}
+	if members == nil {
+		// Group not found
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("name", d.Id()))
+	errs = append(errs, d.Set("login_names", members))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeGroupMembersUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeGroupMembers(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersUpdate: Failed to synchronize members: %+v", err)
+	}
+	return resourceSonarqubeGroupMembersRead(d, m)
+}
+
+func resourceSonarqubeGroupMembersDelete(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("name").(string)
+	members, err := readGroupMemberLoginsFromApi(groupName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersDelete: Failed to read the members of the group: %+v", err)
+	}
+
+	// Only remove the members managed by this resource, members added since the last refresh are kept
+	for _, login := range d.Get("login_names").(*schema.Set).List() {
+		if !slices.Contains(members, login.(string)) {
+			continue
+		}
+		if err := removeGroupMember(groupName, login.(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGroupMembersDelete: Failed to remove user '%s': %+v", login.(string), err)
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeGroupMembersImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeGroupMembersRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// synchronizeGroupMembers adds the declared users which are not members yet and removes every other member of the group
+func synchronizeGroupMembers(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("name").(string)
+
+	managed, err := isManagedGroup(groupName, m)
+	if err != nil {
+		return err
+	}
+	if managed {
+		return fmt.Errorf("group '%s' is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning): its members can only be changed in the identity provider", groupName)
+	}
+
+	members, err := readGroupMemberLoginsFromApi(groupName, m)
+	if err != nil {
+		return err
+	}
+
+	declared := []string{}
+	for _, login := range d.Get("login_names").(*schema.Set).List() {
+		declared = append(declared, login.(string))
+	}
+
+	for _, login := range declared {
+		if slices.Contains(members, login) {
+			continue
+		}
+		if err := addGroupMember(groupName, login, m); err != nil {
+			return fmt.Errorf("synchronizeGroupMembers: Failed to add user '%s': %+v", login, err)
+		}
+	}
+
+	for _, member := range members {
+		if slices.Contains(declared, member) {
+			continue
+		}
+		if err := removeGroupMember(groupName, member, m); err != nil {
+			return fmt.Errorf("synchronizeGroupMembers: Failed to remove user '%s': %+v", member, err)
+		}
+	}
+	return nil
+}
+
+// readGroupMemberLoginsFromApi returns the login of every member of the group, or nil if there is no such group
+func readGroupMemberLoginsFromApi(groupName string, m interface{}) ([]string, error) {
+	members := []string{}
+	page := 1
+	pageSize := 500
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
+		sonarQubeURL.RawQuery = url.Values{
+			"name":     []string{groupName},
+			"selected": []string{"selected"},
+			"p":        []string{strconv.Itoa(page)},
+			"ps":       []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readGroupMemberLoginsFromApi",
+		)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		membersResponse := GetGroupMembersResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(&membersResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readGroupMemberLoginsFromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		for _, member := range membersResponse.Members {
+			members = append(members, member.LoginName)
+		}
+
+		// The paging fields of this endpoint differ between versions, so stop on the first page which is not full
+		if len(membersResponse.Members) < pageSize {
+			return members, nil
+		}
+		page++
+	}
+}
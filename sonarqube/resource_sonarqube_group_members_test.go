@@ -0,0 +1,120 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_group_members", &resource.Sweeper{
+		Name: "sonarqube_group_members",
+		F:    testSweepSonarqubeGroupMembersSweeper,
+	})
+}
+
+func testSweepSonarqubeGroupMembersSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGroupMembersConfig(rnd string, members string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s_first" {
+			login_name = "%[1]s-first"
+			name       = "First User"
+			email      = "%[1]s-first@sonarqube.com"
+			is_local   = false
+		}
+
+		resource "sonarqube_user" "%[1]s_second" {
+			login_name = "%[1]s-second"
+			name       = "Second User"
+			email      = "%[1]s-second@sonarqube.com"
+			is_local   = false
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_group_members" "%[1]s" {
+			name        = sonarqube_group.%[1]s.name
+			login_names = %[2]s
+		}`, rnd, members)
+}
+
+func TestAccSonarqubeGroupMembers(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group_members." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, fmt.Sprintf("[sonarqube_user.%[1]s_first.login_name, sonarqube_user.%[1]s_second.login_name]", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", rnd),
+					resource.TestCheckResourceAttr(name, "login_names.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "login_names.*", rnd+"-first"),
+					resource.TestCheckTypeSetElemAttr(name, "login_names.*", rnd+"-second"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, fmt.Sprintf("[sonarqube_user.%[1]s_second.login_name]", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "login_names.*", rnd+"-second"),
+				),
+			},
+			{
+				// A member added outside of Terraform is planned for removal
+				PreConfig: func() {
+					if err := addGroupMember(rnd, rnd+"-first", testAccProvider.Meta()); err != nil {
+						t.Fatalf("Failed to add a member outside of Terraform: %v", err)
+					}
+				},
+				Config: testAccSonarqubeGroupMembersConfig(rnd, fmt.Sprintf("[sonarqube_user.%[1]s_second.login_name]", rnd)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "login_names.*", rnd+"-second"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// A group deleted outside of Terraform is created again with its members
+				PreConfig: func() {
+					group := resourceSonarqubeGroup().TestResourceData()
+					group.SetId(rnd)
+					if err := group.Set("name", rnd); err != nil {
+						t.Fatalf("Failed to set the group name: %v", err)
+					}
+					if err := resourceSonarqubeGroupDelete(group, testAccProvider.Meta()); err != nil {
+						t.Fatalf("Failed to delete the group outside of Terraform: %v", err)
+					}
+				},
+				Config: testAccSonarqubeGroupMembersConfig(rnd, fmt.Sprintf("[sonarqube_user.%[1]s_second.login_name]", rnd)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "login_names.*", rnd+"-second"),
+				),
+			},
+		},
+	})
+}
@@ -2,9 +2,13 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func init() {
@@ -43,6 +47,7 @@ func TestAccSonarqubeGroupBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", groupName),
 					resource.TestCheckResourceAttr(name, "description", groupDescription),
+					resource.TestCheckResourceAttr(name, "managed", "false"),
 				),
 			},
 		},
@@ -108,3 +113,60 @@ func TestAccSonarqubeGroupImport(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSonarqubeGroupRenamedOutsideTerraform(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group." + rnd
+	groupName := "testAccSonarqubeGroup" + rnd
+	config := testAccSonarqubeGroupBasicConfig(rnd, groupName, "group description")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil && !useV2Api(testAccProvider.Meta().(*ProviderConfiguration)) {
+						t.Skip("Skipping test - groups can only be read by ID with the v2 api")
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", groupName),
+				),
+			},
+			{
+				PreConfig: func() {
+					group, err := readGroupV2FromApi(groupName, testAccProvider.Meta())
+					if err != nil || group == nil {
+						t.Fatalf("Failed to read the group: %v", err)
+					}
+					sonarQubeURL := testAccProvider.Meta().(*ProviderConfiguration).sonarQubeURL
+					sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(group.ID)
+					resp, err := httpRequestHelperWithJson(
+						testAccProvider.Meta().(*ProviderConfiguration).httpClient,
+						"PATCH",
+						sonarQubeURL.String(),
+						map[string]interface{}{"name": groupName + "-renamed"},
+						http.StatusOK,
+						"TestAccSonarqubeGroupRenamedOutsideTerraform",
+					)
+					if err != nil {
+						t.Fatalf("Failed to rename the group outside of Terraform: %v", err)
+					}
+					resp.Body.Close()
+				},
+				// The group is still found by its ID, so it is renamed back instead of created again
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", groupName),
+				),
+			},
+		},
+	})
+}
@@ -1,14 +1,17 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -23,6 +26,23 @@ type NewCodePeriod struct {
 	Inherited      bool   `json:"inherited"`
 }
 
+// GetNewCodePeriods for unmarshalling response body of api/new_code_periods/list
+type GetNewCodePeriods struct {
+	NewCodePeriods []NewCodePeriod `json:"newCodePeriods"`
+}
+
+// ProjectAnalysis used in GetProjectAnalyses
+type ProjectAnalysis struct {
+	Key  string `json:"key"`
+	Date string `json:"date"`
+}
+
+// GetProjectAnalyses for unmarshalling response body of api/project_analyses/search
+type GetProjectAnalyses struct {
+	Paging   Paging            `json:"paging"`
+	Analyses []ProjectAnalysis `json:"analyses"`
+}
+
 // New Code Period types
 type NewCodePeriodType string
 
@@ -33,6 +53,12 @@ const (
 	ReferenceBranch  NewCodePeriodType = "REFERENCE_BRANCH"
 )
 
+// Bounds of the NUMBER_OF_DAYS new code period accepted by SonarQube
+const (
+	minNewCodePeriodDays = 1
+	maxNewCodePeriodDays = 90
+)
+
 // Returns the resource represented by this file.
 func resourceSonarqubeNewCodePeriodsBinding() *schema.Resource {
 	return &schema.Resource{
@@ -44,6 +70,12 @@ func resourceSonarqubeNewCodePeriodsBinding() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeNewCodePeriodsImport,
 		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateNewCodePeriodValue(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -69,7 +101,7 @@ func resourceSonarqubeNewCodePeriodsBinding() *schema.Resource {
 			"value": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The desired value of the new code period. Varies based on the 'type'. For SPECIFIC_ANALYIS, the value must be the UUID of a previous analysis. For NUMBER_OF_DAYS it must be a numeric string. For REFERENCE_BRANCH it should be the name of branch on the project. For PREVIOUS_VERSION it must **not** be set.",
+				Description: "The desired value of the new code period. Varies based on the 'type'. For SPECIFIC_ANALYIS, the value must be the UUID of a previous analysis. For NUMBER_OF_DAYS it must be a numeric string between 1 and 90. For REFERENCE_BRANCH it should be the name of branch on the project. For PREVIOUS_VERSION it must **not** be set. When the project already exists, the analysis (on `branch`, or on the main branch when `branch` is unset) or reference branch is checked to exist at plan time.",
 			},
 		},
 	}
@@ -255,3 +287,124 @@ func resourceSonarqubeNewCodePeriodsImport(d *schema.ResourceData, m interface{}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// Validate that the analysis or reference branch used as value exists on the project
+func validateNewCodePeriodValue(d *schema.ResourceDiff, m interface{}) error {
+	// Only validate changes, a reference branch could have been deleted since it was configured
+	if !d.HasChanges("type", "value", "project", "branch") {
+		return nil
+	}
+	if !d.NewValueKnown("project") || !d.NewValueKnown("branch") || !d.NewValueKnown("value") {
+		return nil
+	}
+
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	value := d.Get("value").(string)
+	periodType := NewCodePeriodType(d.Get("type").(string))
+
+	// The number of days is checked on every scope, the other values only exist within a project
+	if periodType == NumberOfDays && value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < minNewCodePeriodDays || days > maxNewCodePeriodDays {
+			return fmt.Errorf("validateNewCodePeriodValue: 'value' must be a number of days between %d and %d when the 'type' is %s, got '%s'", minNewCodePeriodDays, maxNewCodePeriodDays, periodType, value)
+		}
+	}
+	if project == "" || value == "" {
+		return nil
+	}
+
+	switch periodType {
+	case SpecificAnalysis:
+		if branch == "" {
+			// Without a branch, the analysis has to exist on the main branch
+			branches, err := readProjectBranchesFromApi(project, m)
+			if err != nil {
+				return fmt.Errorf("validateNewCodePeriodValue: Failed to list the branches of project '%s': %+v", project, err)
+			}
+			// The project does not exist yet, it is probably created in the same apply
+			if branches == nil {
+				return nil
+			}
+			for _, b := range branches.Branches {
+				if b.IsMain {
+					branch = b.Name
+				}
+			}
+		}
+		found, projectExists, err := projectAnalysisExists(project, branch, value, m)
+		if err != nil {
+			return fmt.Errorf("validateNewCodePeriodValue: Failed to search the analyses of project '%s': %+v", project, err)
+		}
+		if projectExists && !found {
+			return fmt.Errorf("validateNewCodePeriodValue: analysis '%s' does not exist on branch '%s' of project '%s'", value, branch, project)
+		}
+
+	case ReferenceBranch:
+		branches, err := readProjectBranchesFromApi(project, m)
+		if err != nil {
+			return fmt.Errorf("validateNewCodePeriodValue: Failed to list the branches of project '%s': %+v", project, err)
+		}
+		// The project does not exist yet, it is probably created in the same apply
+		if branches == nil {
+			return nil
+		}
+		for _, b := range branches.Branches {
+			if b.Name == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("validateNewCodePeriodValue: reference branch '%s' does not exist on project '%s'", value, project)
+	}
+
+	return nil
+}
+
+// projectAnalysisExists returns whether the analysis exists on the branch of the project, and whether the project exists
+func projectAnalysisExists(project string, branch string, analysis string, m interface{}) (bool, bool, error) {
+	page := 1
+	pageSize := 500
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"project": []string{project},
+			"branch":  []string{branch},
+			"p":       []string{strconv.Itoa(page)},
+			"ps":      []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"projectAnalysisExists",
+		)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+
+		analysesResponse := GetProjectAnalyses{}
+		if err := json.NewDecoder(resp.Body).Decode(&analysesResponse); err != nil {
+			_ = resp.Body.Close()
+			return false, true, fmt.Errorf("projectAnalysisExists: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		for _, value := range analysesResponse.Analyses {
+			if value.Key == analysis {
+				return true, true, nil
+			}
+		}
+
+		if int64(page*pageSize) >= analysesResponse.Paging.Total || len(analysesResponse.Analyses) == 0 {
+			return false, true, nil
+		}
+		page++
+	}
+}
@@ -200,6 +200,63 @@ func TestAccSonarqubeNewCodePeriodsBranchNumberOfDays(t *testing.T) {
 // 	})
 // }
 
+func testAccSonarqubeNewCodePeriodsUnknownSpecificAnalysis(rnd string, withNewCodePeriod bool) string {
+	newCodePeriod := ""
+	if withNewCodePeriod {
+		newCodePeriod = fmt.Sprintf(`
+        resource "sonarqube_new_code_periods" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+			type = "SPECIFIC_ANALYSIS"
+			value = "AU-Tpxb--iU5OvuD2FLy"
+        }`, rnd)
+	}
+	return fmt.Sprintf(`
+	    resource "sonarqube_project" "%[1]s" {
+			name = "%[1]s"
+			project = "%[1]s"
+			visibility = "public"
+		}
+		%[2]s`, rnd, newCodePeriod)
+}
+
+func TestAccSonarqubeNewCodePeriodsUnknownSpecificAnalysis(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The project has to exist at plan time for the analysis to be validated
+				Config: testAccSonarqubeNewCodePeriodsUnknownSpecificAnalysis(rnd, false),
+			},
+			{
+				Config:      testAccSonarqubeNewCodePeriodsUnknownSpecificAnalysis(rnd, true),
+				ExpectError: regexp.MustCompile("analysis 'AU-Tpxb--iU5OvuD2FLy' does not exist on branch 'main'"),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeNewCodePeriodsNumberOfDaysOutOfRange(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+        resource "sonarqube_new_code_periods" "%[1]s" {
+			type = "NUMBER_OF_DAYS"
+			value = "91"
+        }`, rnd),
+				ExpectError: regexp.MustCompile("must be a number of days between 1 and 90"),
+			},
+		},
+	})
+}
+
 func testAccSonarqubeNewCodePeriodsBranchReferenceBranch(rnd string) string {
 	return fmt.Sprintf(`
 	    resource "sonarqube_project" "%[1]s" {
@@ -1,14 +1,19 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -47,6 +52,11 @@ type BadgeTokenResponse struct {
 	Token string `json:"token"`
 }
 
+// ContainsAiCodeResponse for unmarshalling response body of api/projects/get_contains_ai_code
+type ContainsAiCodeResponse struct {
+	ContainsAiCode bool `json:"contains_ai_code"`
+}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeProject() *schema.Resource {
 	return &schema.Resource{
@@ -58,6 +68,12 @@ func resourceSonarqubeProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeProjectImport,
 		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateProjectContainsAiCode(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -87,6 +103,12 @@ func resourceSonarqubeProject() *schema.Resource {
 				},
 				Description: "A list of tags to put on the project.",
 			},
+			"contains_ai_code": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project contains AI-generated code, which enables AI Code Assurance for the project. Only read from the server when set, or when it is already in the state. Requires SonarQube 10.7 or above.",
+			},
 			"badge_token": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -163,6 +185,100 @@ func projectSetTags(d *schema.ResourceData, m interface{}, sonarQubeURL url.URL)
 	return nil
 }
 
+func projectSetContainsAiCode(d *schema.ResourceData, m interface{}) error {
+	if err := checkAiCodeAssuranceFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/set_contains_ai_code"
+	sonarQubeURL.RawQuery = url.Values{
+		"project":          []string{d.Get("project").(string)},
+		"contains_ai_code": []string{strconv.FormatBool(d.Get("contains_ai_code").(bool))},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"projectSetContainsAiCode",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// projectGetContainsAiCode returns whether the project contains AI code, and whether the server could tell
+func projectGetContainsAiCode(d *schema.ResourceData, m interface{}) (bool, bool, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/get_contains_ai_code"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{d.Get("project").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"projectGetContainsAiCode",
+	)
+	if err != nil {
+		// The credentials may lack the permission, or the edition may not support AI Code Assurance
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][projectGetContainsAiCode] Cannot read whether project '%s' contains AI code: %+v", d.Get("project").(string), err)
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	containsAiCodeResponse := ContainsAiCodeResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&containsAiCodeResponse)
+	if err != nil {
+		return false, false, fmt.Errorf("projectGetContainsAiCode: Failed to decode json into struct: %+v", err)
+	}
+
+	return containsAiCodeResponse.ContainsAiCode, true, nil
+}
+
+// projectContainsAiCodeManaged returns whether contains_ai_code is in the configuration or in the state.
+// Projects that do not use it are not queried, as reading it requires a recent server and extra permissions.
+func projectContainsAiCodeManaged(d *schema.ResourceData) bool {
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() && !rawConfig.GetAttr("contains_ai_code").IsNull() {
+		return true
+	}
+	if rawState := d.GetRawState(); !rawState.IsNull() && rawState.IsKnown() && !rawState.GetAttr("contains_ai_code").IsNull() {
+		return true
+	}
+	return false
+}
+
+// Reject contains_ai_code before the project is created on servers that do not support it
+func validateProjectContainsAiCode(d *schema.ResourceDiff, m interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || rawConfig.GetAttr("contains_ai_code").IsNull() {
+		return nil
+	}
+	if err := checkAiCodeAssuranceFeatureSupport(m.(*ProviderConfiguration)); err != nil {
+		return fmt.Errorf("validateProjectContainsAiCode: contains_ai_code cannot be set: %+v", err)
+	}
+	return nil
+}
+
+func checkAiCodeAssuranceFeatureSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.7")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for AI Code Assurance is %s", minimumVersion)
+	}
+	return nil
+}
+
 func resourceSonarqubeProjectCreate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/create"
@@ -199,6 +315,12 @@ func resourceSonarqubeProjectCreate(d *schema.ResourceData, m interface{}) error
 
 	d.SetId(projectResponse.Project.Key)
 
+	if rawContainsAiCode := d.GetRawConfig().GetAttr("contains_ai_code"); !rawContainsAiCode.IsNull() {
+		if err := projectSetContainsAiCode(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectCreate: Failed to set whether the project contains AI code: %+v", err)
+		}
+	}
+
 	// Set settings
 	_, err = synchronizeSettings(d, m)
 	if err != nil {
@@ -273,6 +395,18 @@ func resourceSonarqubeProjectRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if projectContainsAiCodeManaged(d) && checkAiCodeAssuranceFeatureSupport(m.(*ProviderConfiguration)) == nil {
+		containsAiCode, found, err := projectGetContainsAiCode(d, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectRead: Failed to get whether the project contains AI code: %+v", err)
+		}
+		if found {
+			if err := d.Set("contains_ai_code", containsAiCode); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Get settings
 	var projectSettings []Setting
 	if _, ok := d.GetOk("setting"); ok {
@@ -285,10 +419,10 @@ func resourceSonarqubeProjectRead(d *schema.ResourceData, m interface{}) error {
 		var settings []interface{}
 		var settingsKey []string
 		if len(componentSettings) > 0 {
-			// looks for backend value for defined settings
+			// looks for backend value for defined settings, skipping values which are inherited rather than set on the project
 			for _, s := range componentSettings {
 				for _, apiSetting := range projectSettings {
-					if s.(map[string]interface{})["key"].(string) == apiSetting.Key {
+					if s.(map[string]interface{})["key"].(string) == apiSetting.Key && !apiSetting.Inherited {
 						settings = append(settings, apiSetting.ToMap())
 						settingsKey = append(settingsKey, apiSetting.Key)
 					}
@@ -373,6 +507,12 @@ func resourceSonarqubeProjectUpdate(d *schema.ResourceData, m interface{}) error
 		}
 	}
 
+	if d.HasChange("contains_ai_code") {
+		if err := projectSetContainsAiCode(d, m); err != nil {
+			return fmt.Errorf("error updating whether the Sonarqube project contains AI code: %+v", err)
+		}
+	}
+
 	if d.HasChange("setting") {
 		_, err := synchronizeSettings(d, m)
 		if err != nil {
@@ -149,3 +149,36 @@ func resourceSonarqubeProjectMainBranchImport(d *schema.ResourceData, m interfac
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// readProjectBranchesFromApi returns the branches of the project, or nil if the project does not exist
+func readProjectBranchesFromApi(project string, m interface{}) (*GetBranches, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_branches/list"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectBranchesFromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	branchReadResponse := GetBranches{}
+	err = json.NewDecoder(resp.Body).Decode(&branchReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectBranchesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &branchReadResponse, nil
+}
@@ -2,10 +2,13 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
@@ -440,3 +443,133 @@ func TestAccSonarqubeProjectBadgeToken(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeProjectContainsAiCodeConfig(rnd string, name string, containsAiCode bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+		  name             = "%[2]s"
+		  project          = "%[2]s"
+		  visibility       = "public"
+		  contains_ai_code = %[3]t
+		}
+		`, rnd, name, containsAiCode)
+}
+
+func TestAccSonarqubeProjectContainsAiCode(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						if err := checkAiCodeAssuranceFeatureSupport(testAccProvider.Meta().(*ProviderConfiguration)); err != nil {
+							t.Skipf("Skipping AI Code Assurance test - %s", err)
+						}
+					}
+				},
+				Config: testAccSonarqubeProjectContainsAiCodeConfig(rnd, "testAccSonarqubeProjectContainsAiCode", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "contains_ai_code", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectContainsAiCodeConfig(rnd, "testAccSonarqubeProjectContainsAiCode", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "contains_ai_code", "false"),
+				),
+			},
+		},
+	})
+}
+
+// Setting contains_ai_code on a server without AI Code Assurance fails at plan time, before the project is created
+func TestAccSonarqubeProjectContainsAiCodeUnsupported(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						if err := checkAiCodeAssuranceFeatureSupport(testAccProvider.Meta().(*ProviderConfiguration)); err == nil {
+							t.Skip("Skipping test - AI Code Assurance is supported by this version of SonarQube")
+						}
+					}
+				},
+				Config:      testAccSonarqubeProjectContainsAiCodeConfig(rnd, "testAccSonarqubeProjectContainsAiCodeUnsupported", true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("minimum required SonarQube version for AI Code Assurance is 10.7"),
+			},
+		},
+	})
+}
+
+func TestProjectGetContainsAiCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		expectedValue  bool
+		expectedFound  bool
+		expectingError bool
+	}{
+		{
+			name:          "project contains AI code",
+			statusCode:    http.StatusOK,
+			body:          `{"contains_ai_code": true}`,
+			expectedValue: true,
+			expectedFound: true,
+		},
+		{
+			name:          "missing permission",
+			statusCode:    http.StatusForbidden,
+			body:          `{"errors": [{"msg": "Insufficient privileges"}]}`,
+			expectedFound: false,
+		},
+		{
+			name:          "unsupported edition",
+			statusCode:    http.StatusNotFound,
+			body:          `{"errors": [{"msg": "Unknown url"}]}`,
+			expectedFound: false,
+		},
+		{
+			name:           "server error",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"errors": [{"msg": "Bad request"}]}`,
+			expectingError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := testStubServer(t, map[string]http.HandlerFunc{
+				"/api/projects/get_contains_ai_code": testStubResponse(tt.statusCode, tt.body),
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceSonarqubeProject().Schema, map[string]interface{}{
+				"name":    "my-project",
+				"project": "my-project",
+			})
+
+			containsAiCode, found, err := projectGetContainsAiCode(d, testProviderConfiguration(t, host, "10.7"))
+			if tt.expectingError {
+				if err == nil {
+					t.Errorf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if found != tt.expectedFound || containsAiCode != tt.expectedValue {
+				t.Errorf("Expected (%t, %t), got (%t, %t)", tt.expectedValue, tt.expectedFound, containsAiCode, found)
+			}
+		})
+	}
+}
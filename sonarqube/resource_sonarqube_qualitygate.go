@@ -1,16 +1,21 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ReadQualityGateConditionsResponse for unmarshalling response body of Quality Gate read
@@ -28,6 +33,9 @@ type GetQualityGate struct {
 	Conditions []ReadQualityGateConditionsResponse `json:"conditions"`
 	IsBuiltIn  bool                                `json:"isBuiltIn"`
 	Actions    QualityGateActions                  `json:"actions"`
+	// Only returned by SonarQube 10.x and above
+	CaycStatus        string `json:"caycStatus"`
+	IsAiCodeSupported bool   `json:"isAiCodeSupported"`
 }
 
 // QualityGateActions used in GetQualityGate
@@ -45,6 +53,29 @@ type CreateQualityGateResponse struct {
 	Name string `json:"name"`
 }
 
+// Metric used in GetMetrics
+type Metric struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Domain string `json:"domain"`
+	Hidden bool   `json:"hidden"`
+}
+
+// GetMetrics for unmarshalling response body of api/metrics/search
+type GetMetrics struct {
+	Metrics []Metric `json:"metrics"`
+	Total   int64    `json:"total"`
+	P       int      `json:"p"`
+	PS      int      `json:"ps"`
+}
+
+// Metric types that can be used in a quality gate condition
+var qualityGateConditionMetricTypes = []string{"INT", "MILLISEC", "RATING", "WORK_DUR", "FLOAT", "PERCENT", "LEVEL"}
+
+// Metrics that cannot be used in a quality gate condition
+var qualityGateConditionForbiddenMetrics = []string{"alert_status", "security_hotspots", "new_security_hotspots"}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityGate() *schema.Resource {
 	return &schema.Resource{
@@ -56,6 +87,18 @@ func resourceSonarqubeQualityGate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityGateImport,
 		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityGateConditionMetricsUnique(d)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityGateConditions(d, meta)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityGateCaycCompliance(d)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -77,10 +120,34 @@ func resourceSonarqubeQualityGate() *schema.Resource {
 				Description: "When set to true this Quality Gate is set as default.",
 				Default:     false,
 			},
+			"require_cayc_compliant": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, the plan fails if the Quality Gate is not compliant with the \"Clean as You Code\" methodology. When the conditions change, the compliance is checked once they are applied. Requires SonarQube 10.0 or above. Defaults to `false`.",
+			},
+			"cayc_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the Quality Gate is compliant with the \"Clean as You Code\" methodology. One of `compliant`, `non-compliant` or `over-compliant`. Empty on versions of SonarQube that do not report it.",
+			},
+			"ai_code_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Quality Gate qualifies for AI Code Assurance.",
+			},
+			"manage_conditions": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       true,
+				ConflictsWith: []string{"condition"},
+				Description:   "When set to false, the conditions of the gate are not managed by this resource, so they can be managed with `sonarqube_qualitygate_condition` resources instead. Defaults to `true`.",
+			},
 			"condition": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "A list of conditions that the gate uses.",
+				Set:         hashQualityGateCondition,
+				Description: "A set of conditions that the gate uses. Conditions are identified by their metric, so each metric can only be used once and changing the `op` or `threshold` of a condition updates it in place. The conditions are validated against the metrics of the server at plan time.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -109,14 +176,15 @@ func resourceSonarqubeQualityGate() *schema.Resource {
   - new_security_hotspots`,
 						},
 						"op": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Condition operator. Possible values are: LT and GT",
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Condition operator. Possible values are: LT and GT",
+							ValidateFunc: validation.StringInSlice([]string{"LT", "GT"}, false),
 						},
 						"threshold": {
 							Type:        schema.TypeString,
 							Required:    true,
-							Description: "Condition error threshold (For ratings: A=1, B=2, C=3, D=4)",
+							Description: "Condition error threshold (For ratings: A=1, B=2, C=3, D=4, E=5)",
 						},
 					},
 				},
@@ -137,9 +205,6 @@ func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) e
 			"sourceName": []string{gate_to_copy.(string)},
 		}.Encode()
 	} else {
-		if _, ok := d.GetOk("condition"); !ok {
-			return fmt.Errorf("resourceQualityGateCreate: either copy_from or at least one condition block must be specified for a quality gate")
-		}
 		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/create"
 		sonarQubeURL.RawQuery = url.Values{
 			"name": []string{d.Get("name").(string)},
@@ -175,7 +240,7 @@ func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) e
 	// SonarQube 9.9 and above will automatically create "Clean as you code" conditions for new quality gates
 	// If we are not copying a gate then we need to synchronise the conditions from the newly created gate with
 	// the ones declared on the terraform resource
-	if !copying_gate {
+	if !copying_gate && qualityGateConditionsManaged(d) {
 		changes, err := synchronizeConditions(d, m, &qualityGateReadResponse.Conditions)
 		if err != nil {
 			return fmt.Errorf("resourceSonarqubeQualityGateCreate: Failed to synchronise quality gate conditions: %+v", err)
@@ -196,10 +261,24 @@ func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	return updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse)
+	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse); err != nil {
+		return err
+	}
+	return checkQualityGateCaycCompliance(d, qualityGateReadResponse)
 }
 
 func resourceSonarqubeQualityGateRead(d *schema.ResourceData, m interface{}) error {
+	// Imported gates, and gates created before manage_conditions existed, have their conditions managed
+	if _, ok := d.GetOkExists("manage_conditions"); !ok {
+		if err := d.Set("manage_conditions", true); err != nil {
+			return err
+		}
+	}
+	return readQualityGateResourceData(d, m)
+}
+
+// readQualityGateResourceData reads the quality gate into the resource or data source
+func readQualityGateResourceData(d *schema.ResourceData, m interface{}) error {
 	qualityGateReadResponse, err := readQualityGateFromApi(d, m)
 	if err != nil {
 		return err
@@ -216,10 +295,6 @@ var lock_update_default sync.Mutex
 func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) error {
 	_, copied_gate := d.GetOk("copy_from")
 
-	if _, has_conditions := d.GetOk("condition"); !(copied_gate || has_conditions) {
-		return fmt.Errorf("resourceQualityGateCreate: either copy_from or at least one condition block must be specified for a quality gate")
-	}
-
 	if d.HasChange("name") {
 		err := updateQualityGateName(d, m)
 		if err != nil {
@@ -236,7 +311,7 @@ func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) e
 	conditionsChanged := false
 
 	// We only need to update the conditions if this is not a copied gate - they will still exist from when it was created originally
-	if !copied_gate {
+	if !copied_gate && qualityGateConditionsManaged(d) {
 		conditionsChanged, err = synchronizeConditions(d, m, &qualityGateReadResponse.Conditions)
 		if err != nil {
 			return fmt.Errorf("resourceSonarqubeQualityGateUpdate: Failed to synchronise quality gate conditions: %+v", err)
@@ -270,7 +345,10 @@ func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	return updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse)
+	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse); err != nil {
+		return err
+	}
+	return checkQualityGateCaycCompliance(d, qualityGateReadResponse)
 }
 
 func resourceSonarqubeQualityGateDelete(d *schema.ResourceData, m interface{}) error {
@@ -340,11 +418,27 @@ func setDefaultQualityGate(d *schema.ResourceData, m interface{}, setDefault boo
 }
 
 func readQualityGateFromApi(d *schema.ResourceData, m interface{}) (*GetQualityGate, error) {
+	return readQualityGateByNameFromApi(d.Id(), m)
+}
+
+func readQualityGateByNameFromApi(name string, m interface{}) (*GetQualityGate, error) {
+	qualityGate, err := findQualityGateByNameFromApi(name, m)
+	if err != nil {
+		return nil, err
+	}
+	if qualityGate == nil {
+		return nil, fmt.Errorf("readQualityGateFromApi: quality gate '%s' does not exist", name)
+	}
+	return qualityGate, nil
+}
+
+// findQualityGateByNameFromApi returns the quality gate, or nil when it does not exist
+func findQualityGateByNameFromApi(name string, m interface{}) (*GetQualityGate, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/show"
 
 	sonarQubeURL.RawQuery = url.Values{
-		"name": []string{d.Id()},
+		"name": []string{name},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -355,6 +449,9 @@ func readQualityGateFromApi(d *schema.ResourceData, m interface{}) (*GetQualityG
 		"readQualityGateFromApi",
 	)
 	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("readQualityGateFromApi: Failed to call api/qualitygates/show: %+v", err)
 	}
 	defer resp.Body.Close()
@@ -374,9 +471,16 @@ func readQualityGateFromApi(d *schema.ResourceData, m interface{}) (*GetQualityG
 	return &qualityGateReadResponse, nil
 }
 
+// qualityGateConditionsManaged returns whether the conditions of the gate are managed.
+// The data source has no manage_conditions and always reads the conditions.
+func qualityGateConditionsManaged(d *schema.ResourceData) bool {
+	manageConditions, ok := d.GetOkExists("manage_conditions")
+	return !ok || manageConditions.(bool)
+}
+
 func synchronizeConditions(d *schema.ResourceData, m interface{}, apiQualityGateConditions *[]ReadQualityGateConditionsResponse) (bool, error) {
 	changed := false
-	qualityGateConditions := d.Get("condition").([]interface{})
+	qualityGateConditions := d.Get("condition").(*schema.Set).List()
 
 	// Make sure the order is always the same for when we are comparing lists of conditions
 	sort.Slice(qualityGateConditions, func(i, j int) bool {
@@ -461,8 +565,10 @@ func updateResourceDataFromQualityGateReadResponse(d *schema.ResourceData, quali
 	d.SetId(qualityGateReadResponse.Name)
 	errs := []error{}
 	errs = append(errs, d.Set("name", qualityGateReadResponse.Name))
-	// Copied gates do not have condition blocks so we don't want to populate from the API.
-	if _, copiedGate := d.GetOk("copy_from"); !copiedGate {
+	errs = append(errs, d.Set("cayc_status", qualityGateReadResponse.CaycStatus))
+	errs = append(errs, d.Set("ai_code_supported", qualityGateReadResponse.IsAiCodeSupported))
+	// Copied gates, and gates whose conditions are not managed, do not have condition blocks so we don't want to populate from the API.
+	if _, copiedGate := d.GetOk("copy_from"); !copiedGate && qualityGateConditionsManaged(d) {
 		errs = append(errs, d.Set("condition", flattenReadQualityGateConditionsResponse(&qualityGateReadResponse.Conditions)))
 	}
 	return errors.Join(errs...)
@@ -593,3 +699,165 @@ func flattenReadQualityGateConditionsResponse(input *[]ReadQualityGateConditions
 
 	return flatConditions
 }
+
+// hashQualityGateCondition identifies a condition by its metric, so that changing the operator or threshold updates it in place
+func hashQualityGateCondition(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["metric"].(string))
+}
+
+// Conditions are hashed on their metric, so conditions sharing a metric would silently collapse into one.
+// The configuration is checked instead of the set to catch them.
+func validateQualityGateConditionMetricsUnique(d *schema.ResourceDiff) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	conditions := rawConfig.GetAttr("condition")
+	if conditions.IsNull() || !conditions.IsKnown() {
+		return nil
+	}
+
+	metrics := map[string]bool{}
+	for it := conditions.ElementIterator(); it.Next(); {
+		_, condition := it.Element()
+		metric := condition.GetAttr("metric")
+		if metric.IsNull() || !metric.IsKnown() {
+			continue
+		}
+		if metrics[metric.AsString()] {
+			return fmt.Errorf("validateQualityGateConditionMetricsUnique: metric '%s' is used by more than one condition", metric.AsString())
+		}
+		metrics[metric.AsString()] = true
+	}
+	return nil
+}
+
+// Validate the conditions against the metrics known by the server
+func validateQualityGateConditions(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("condition") || !d.NewValueKnown("condition") {
+		return nil
+	}
+	conditions := d.Get("condition").(*schema.Set).List()
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	metrics, err := readMetricsFromApi(m)
+	if err != nil {
+		return fmt.Errorf("validateQualityGateConditions: Failed to read the metrics: %+v", err)
+	}
+
+	for _, c := range conditions {
+		condition := c.(map[string]interface{})
+		if err := validateQualityGateCondition(condition["metric"].(string), condition["op"].(string), condition["threshold"].(string), metrics); err != nil {
+			return fmt.Errorf("validateQualityGateConditions: %+v", err)
+		}
+	}
+	return nil
+}
+
+// Fail the plan when the Quality Gate is required to be "Clean as You Code" compliant but is not
+func validateQualityGateCaycCompliance(d *schema.ResourceDiff) error {
+	// The compliance can only be known once the conditions have been applied
+	if d.Id() == "" || d.HasChanges("condition", "copy_from") {
+		return d.SetNewComputed("cayc_status")
+	}
+	if !d.Get("require_cayc_compliant").(bool) {
+		return nil
+	}
+	if err := qualityGateCaycComplianceError(d.Get("name").(string), d.Get("cayc_status").(string)); err != nil {
+		return fmt.Errorf("validateQualityGateCaycCompliance: %+v", err)
+	}
+	return nil
+}
+
+// checkQualityGateCaycCompliance returns an error when the Quality Gate is required to be "Clean as You Code" compliant but is not
+func checkQualityGateCaycCompliance(d *schema.ResourceData, qualityGate *GetQualityGate) error {
+	if !d.Get("require_cayc_compliant").(bool) {
+		return nil
+	}
+	if err := qualityGateCaycComplianceError(qualityGate.Name, qualityGate.CaycStatus); err != nil {
+		return fmt.Errorf("checkQualityGateCaycCompliance: %+v", err)
+	}
+	return nil
+}
+
+// qualityGateCaycComplianceError returns an error unless the status is compliant (or over-compliant)
+func qualityGateCaycComplianceError(name string, status string) error {
+	switch status {
+	case "compliant", "over-compliant":
+		return nil
+	case "":
+		return fmt.Errorf("the \"Clean as You Code\" status of quality gate '%s' is not reported by this version of SonarQube", name)
+	default:
+		return fmt.Errorf("quality gate '%s' is not \"Clean as You Code\" compliant (status: '%s')", name, status)
+	}
+}
+
+// validateQualityGateCondition checks that the metric can be used in a condition and that the operator and threshold are valid for it
+func validateQualityGateCondition(metric string, op string, threshold string, metrics []Metric) error {
+	if slices.Contains(qualityGateConditionForbiddenMetrics, metric) {
+		return fmt.Errorf("metric '%s' cannot be used in a quality gate condition", metric)
+	}
+
+	index := slices.IndexFunc(metrics, func(value Metric) bool { return value.Key == metric })
+	if index == -1 {
+		return fmt.Errorf("metric '%s' does not exist", metric)
+	}
+	metricType := metrics[index].Type
+	if !slices.Contains(qualityGateConditionMetricTypes, metricType) {
+		return fmt.Errorf("metric '%s' is of type %s, only metrics of type %s can be used in a quality gate condition", metric, metricType, strings.Join(qualityGateConditionMetricTypes, ", "))
+	}
+
+	if op != "LT" && op != "GT" {
+		return fmt.Errorf("operator '%s' of metric '%s' is invalid, it must be LT or GT", op, metric)
+	}
+
+	if metricType == "RATING" {
+		rating, err := strconv.Atoi(threshold)
+		if err != nil || rating < 1 || rating > 5 {
+			return fmt.Errorf("threshold '%s' of metric '%s' is invalid, ratings must be between 1 (A) and 5 (E)", threshold, metric)
+		}
+	}
+	return nil
+}
+
+// readMetricsFromApi returns every metric of the server
+func readMetricsFromApi(m interface{}) ([]Metric, error) {
+	metrics := []Metric{}
+	page := 1
+	pageSize := 500
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/metrics/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"p":  []string{strconv.Itoa(page)},
+			"ps": []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readMetricsFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		metricsResponse := GetMetrics{}
+		if err := json.NewDecoder(resp.Body).Decode(&metricsResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readMetricsFromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		metrics = append(metrics, metricsResponse.Metrics...)
+		if int64(page*pageSize) >= metricsResponse.Total || len(metricsResponse.Metrics) == 0 {
+			return metrics, nil
+		}
+		page++
+	}
+}
@@ -0,0 +1,204 @@
+package sonarqube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityGateCondition() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Quality Gate Condition resource. This can be used to manage a single condition of a Quality Gate.
+
+The Quality Gate must be copied with ` + "`copy_from`" + ` or set ` + "`manage_conditions = false`" + `, otherwise both resources will try to manage the conditions of the gate.`,
+		Create: resourceSonarqubeQualityGateConditionCreate,
+		Read:   resourceSonarqubeQualityGateConditionRead,
+		Update: resourceSonarqubeQualityGateConditionUpdate,
+		Delete: resourceSonarqubeQualityGateConditionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityGateConditionImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityGateConditionResource(d, meta)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"gatename": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Quality Gate. Changing this forces a new resource to be created.",
+			},
+			"metric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Condition metric. Only metrics of type INT, MILLISEC, RATING, WORK_DUR, FLOAT, PERCENT and LEVEL are allowed, except alert_status, security_hotspots and new_security_hotspots. Changing this forces a new resource to be created.",
+			},
+			"op": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Condition operator. Possible values are: LT and GT",
+				ValidateFunc: validation.StringInSlice([]string{"LT", "GT"}, false),
+			},
+			"threshold": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Condition error threshold (For ratings: A=1, B=2, C=3, D=4, E=5)",
+			},
+		},
+	}
+}
+
+// Validate the condition against the metrics known by the server
+func validateQualityGateConditionResource(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges("metric", "op", "threshold") {
+		return nil
+	}
+	if !d.NewValueKnown("metric") || !d.NewValueKnown("op") || !d.NewValueKnown("threshold") {
+		return nil
+	}
+
+	metrics, err := readMetricsFromApi(m)
+	if err != nil {
+		return fmt.Errorf("validateQualityGateConditionResource: Failed to read the metrics: %+v", err)
+	}
+	if err := validateQualityGateCondition(d.Get("metric").(string), d.Get("op").(string), d.Get("threshold").(string), metrics); err != nil {
+		return fmt.Errorf("validateQualityGateConditionResource: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityGateConditionCreate(d *schema.ResourceData, m interface{}) error {
+	gateName := d.Get("gatename").(string)
+	metric := d.Get("metric").(string)
+
+	qualityGate, err := readQualityGateByNameFromApi(gateName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionCreate: Failed to read the quality gate from the API: %+v", err)
+	}
+	if findQualityGateCondition(qualityGate, metric) != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionCreate: quality gate '%s' already has a condition on metric '%s', import it instead", gateName, metric)
+	}
+
+	if _, err := createCondition(gateName, metric, d.Get("op").(string), d.Get("threshold").(string), m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionCreate: Failed to create condition '%s': %+v", metric, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", gateName, metric))
+	return resourceSonarqubeQualityGateConditionRead(d, m)
+}
+
+func resourceSonarqubeQualityGateConditionRead(d *schema.ResourceData, m interface{}) error {
+	gateName, metric, err := parseQualityGateConditionId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	qualityGate, err := findQualityGateByNameFromApi(gateName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionRead: Failed to read the quality gate from the API: %+v", err)
+	}
+	if qualityGate == nil {
+		// The quality gate has been removed outside of Terraform, and its conditions with it
+		d.SetId("")
+		return nil
+	}
+
+	condition := findQualityGateCondition(qualityGate, metric)
+	if condition == nil {
+		// The condition has been removed outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("gatename", qualityGate.Name))
+	errs = append(errs, d.Set("metric", condition.Metric))
+	errs = append(errs, d.Set("op", condition.OP))
+	errs = append(errs, d.Set("threshold", condition.Error))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeQualityGateConditionUpdate(d *schema.ResourceData, m interface{}) error {
+	gateName := d.Get("gatename").(string)
+	metric := d.Get("metric").(string)
+
+	qualityGate, err := readQualityGateByNameFromApi(gateName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionUpdate: Failed to read the quality gate from the API: %+v", err)
+	}
+	condition := findQualityGateCondition(qualityGate, metric)
+	if condition == nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionUpdate: quality gate '%s' has no condition on metric '%s'", gateName, metric)
+	}
+
+	if err := updateCondition(condition.ID, metric, d.Get("op").(string), d.Get("threshold").(string), m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionUpdate: Failed to update condition '%s': %+v", metric, err)
+	}
+	return resourceSonarqubeQualityGateConditionRead(d, m)
+}
+
+func resourceSonarqubeQualityGateConditionDelete(d *schema.ResourceData, m interface{}) error {
+	gateName := d.Get("gatename").(string)
+	metric := d.Get("metric").(string)
+
+	qualityGate, err := findQualityGateByNameFromApi(gateName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionDelete: Failed to read the quality gate from the API: %+v", err)
+	}
+	if qualityGate == nil {
+		return nil
+	}
+	condition := findQualityGateCondition(qualityGate, metric)
+	if condition == nil {
+		return nil
+	}
+
+	if err := deleteCondition(condition.ID, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateConditionDelete: Failed to delete condition '%s': %+v", metric, err)
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityGateConditionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if err := resourceSonarqubeQualityGateConditionRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityGateConditionImport: condition '%s' does not exist", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseQualityGateConditionId splits an id of the form gate/metric. The gate name may contain slashes, the metric key cannot.
+func parseQualityGateConditionId(id string) (string, string, error) {
+	index := strings.LastIndex(id, "/")
+	if index <= 0 || index == len(id)-1 {
+		return "", "", fmt.Errorf("invalid import ID format '%s', expected 'gate/metric'", id)
+	}
+	return id[:index], id[index+1:], nil
+}
+
+// findQualityGateCondition returns the condition of the quality gate on the metric, or nil
+func findQualityGateCondition(qualityGate *GetQualityGate, metric string) *ReadQualityGateConditionsResponse {
+	index := slices.IndexFunc(qualityGate.Conditions, func(condition ReadQualityGateConditionsResponse) bool {
+		return condition.Metric == metric
+	})
+	if index == -1 {
+		return nil
+	}
+	return &qualityGate.Conditions[index]
+}
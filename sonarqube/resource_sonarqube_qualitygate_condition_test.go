@@ -0,0 +1,198 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualitygate_condition", &resource.Sweeper{
+		Name: "sonarqube_qualitygate_condition",
+		F:    testSweepSonarqubeQualitygateConditionSweeper,
+	})
+}
+
+func testSweepSonarqubeQualitygateConditionSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualitygateConditionResourceConfig(rnd string, name string, metric string, threshold string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name      = "%[2]s"
+			copy_from = "Sonar way"
+		}
+
+		resource "sonarqube_qualitygate_condition" "%[1]s" {
+			gatename  = sonarqube_qualitygate.%[1]s.name
+			metric    = "%[3]s"
+			op        = "LT"
+			threshold = "%[4]s"
+		}`, rnd, name, metric, threshold)
+}
+
+func TestAccSonarqubeQualitygateConditionBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate_condition." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualitygateConditionResourceConfig(rnd, "testAccSonarqubeQualitygateCondition", "coverage", "60"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeQualitygateCondition/coverage"),
+					resource.TestCheckResourceAttr(name, "gatename", "testAccSonarqubeQualitygateCondition"),
+					resource.TestCheckResourceAttr(name, "metric", "coverage"),
+					resource.TestCheckResourceAttr(name, "op", "LT"),
+					resource.TestCheckResourceAttr(name, "threshold", "60"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualitygateConditionResourceConfig(rnd, "testAccSonarqubeQualitygateCondition", "coverage", "70"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "threshold", "70"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  name,
+				ImportState:   true,
+				ImportStateId: "testAccSonarqubeQualitygateCondition",
+				ExpectError:   regexp.MustCompile("invalid import ID format"),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeQualitygateConditionInvalidMetric(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualitygateConditionResourceConfig(rnd, "testAccSonarqubeQualitygateConditionInvalid", "alert_status", "1"),
+				ExpectError: regexp.MustCompile("metric 'alert_status' cannot be used in a quality gate condition"),
+			},
+		},
+	})
+}
+
+// The conditions of a gate created with manage_conditions = false are left to the condition resources
+func TestAccSonarqubeQualitygateConditionUnmanagedGate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate_condition." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_qualitygate" "%[1]s" {
+						name              = "testAccSonarqubeQualitygateConditionUnmanaged"
+						manage_conditions = false
+					}
+
+					resource "sonarqube_qualitygate_condition" "%[1]s" {
+						gatename  = sonarqube_qualitygate.%[1]s.name
+						metric    = "new_duplicated_lines_density"
+						op        = "GT"
+						threshold = "5"
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sonarqube_qualitygate."+rnd, "manage_conditions", "false"),
+					resource.TestCheckResourceAttr("sonarqube_qualitygate."+rnd, "condition.#", "0"),
+					resource.TestCheckResourceAttr(name, "threshold", "5"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSonarqubeQualityGateApi calls api/qualitygates/<action> for the quality gate, outside of Terraform
+func testAccSonarqubeQualityGateApi(t *testing.T, action string, name string, expectedStatusCode int) {
+	conf := testAccProvider.Meta().(*ProviderConfiguration)
+	sonarQubeURL := conf.sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/" + action
+	sonarQubeURL.RawQuery = url.Values{
+		"name": []string{name},
+	}.Encode()
+
+	resp, err := httpRequestHelper(conf.httpClient, "POST", sonarQubeURL.String(), expectedStatusCode, "testAccSonarqubeQualityGateApi")
+	if err != nil {
+		t.Fatalf("Failed to %s quality gate '%s' outside of Terraform: %v", action, name, err)
+	}
+	defer resp.Body.Close()
+}
+
+// The condition is removed from state when it or its quality gate is removed outside of Terraform
+func TestAccSonarqubeQualitygateConditionRemoved(t *testing.T) {
+	rnd := generateRandomResourceName()
+	gateName := "testAccSonarqubeQualitygateConditionRemoved"
+	name := "sonarqube_qualitygate_condition." + rnd
+	config := fmt.Sprintf(`
+		resource "sonarqube_qualitygate_condition" "%[1]s" {
+			gatename  = "%[2]s"
+			metric    = "coverage"
+			op        = "LT"
+			threshold = "60"
+		}`, rnd, gateName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccSonarqubeQualityGateApi(t, "create", gateName, http.StatusOK)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", gateName+"/coverage"),
+				),
+			},
+			{
+				PreConfig: func() {
+					qualityGate, err := readQualityGateByNameFromApi(gateName, testAccProvider.Meta())
+					if err != nil {
+						t.Fatalf("Failed to read the quality gate: %v", err)
+					}
+					if err := deleteCondition(findQualityGateCondition(qualityGate, "coverage").ID, testAccProvider.Meta()); err != nil {
+						t.Fatalf("Failed to delete the condition outside of Terraform: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "threshold", "60"),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccSonarqubeQualityGateApi(t, "destroy", gateName, http.StatusNoContent)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,283 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityGateProject used in GetQualityGateProjects
+type QualityGateProject struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Selected bool   `json:"selected"`
+}
+
+// GetQualityGateProjects for unmarshalling response body of api/qualitygates/search
+type GetQualityGateProjects struct {
+	Paging  Paging               `json:"paging"`
+	Results []QualityGateProject `json:"results"`
+	More    bool                 `json:"more"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityGateProjects() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Quality Gate Projects resource. This can be used to associate many Projects to a Quality Gate at once.
+
+The projects associated to the Quality Gate are read with a single (paginated) search, and only the differences are applied.
+This resource should not be combined with ` + "`sonarqube_qualitygate_project_association`" + ` resources for the same Quality Gate.`,
+		Create: resourceSonarqubeQualityGateProjectsCreate,
+		Read:   resourceSonarqubeQualityGateProjectsRead,
+		Update: resourceSonarqubeQualityGateProjectsUpdate,
+		Delete: resourceSonarqubeQualityGateProjectsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityGateProjectsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"gatename": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Quality Gate. Changing this forces a new resource to be created.",
+			},
+			"projects": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The keys of the projects to associate to the Quality Gate.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, projects associated to the Quality Gate which are not listed in `projects` are moved back to the default Quality Gate. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityGateProjectsCreate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeQualityGateProjects(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateProjectsCreate: Failed to synchronize projects: %+v", err)
+	}
+
+	d.SetId(d.Get("gatename").(string))
+	return resourceSonarqubeQualityGateProjectsRead(d, m)
+}
+
+func resourceSonarqubeQualityGateProjectsRead(d *schema.ResourceData, m interface{}) error {
+	selected, err := readQualityGateProjectsFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateProjectsRead: Failed to read the projects of the quality gate: %+v", err)
+	}
+
+	projects := []interface{}{}
+	if d.Get("authoritative").(bool) {
+		for _, project := range selected {
+			projects = append(projects, project)
+		}
+	} else {
+		// Only keep track of the declared projects, other projects may be associated by other means
+		for _, project := range d.Get("projects").(*schema.Set).List() {
+			if slices.Contains(selected, project.(string)) {
+				projects = append(projects, project)
+			}
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("gatename", d.Id()))
+	errs = append(errs, d.Set("projects", projects))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeQualityGateProjectsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeQualityGateProjects(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateProjectsUpdate: Failed to synchronize projects: %+v", err)
+	}
+	return resourceSonarqubeQualityGateProjectsRead(d, m)
+}
+
+func resourceSonarqubeQualityGateProjectsDelete(d *schema.ResourceData, m interface{}) error {
+	gateName := d.Get("gatename").(string)
+	selected, err := readQualityGateProjectsFromApi(gateName, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateProjectsDelete: Failed to read the projects of the quality gate: %+v", err)
+	}
+
+	for _, project := range d.Get("projects").(*schema.Set).List() {
+		if !slices.Contains(selected, project.(string)) {
+			continue
+		}
+		if err := deselectQualityGateProject(gateName, project.(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityGateProjectsDelete: Failed to deselect project '%s': %+v", project, err)
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityGateProjectsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import every project associated to the quality gate, since there is no configuration to compare with yet
+	if err := d.Set("authoritative", true); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeQualityGateProjectsRead(d, m); err != nil {
+		return nil, err
+	}
+	if err := d.Set("authoritative", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// synchronizeQualityGateProjects selects the declared projects which are not associated yet and deselects the projects that should no longer be associated
+func synchronizeQualityGateProjects(d *schema.ResourceData, m interface{}) error {
+	gateName := d.Get("gatename").(string)
+	selected, err := readQualityGateProjectsFromApi(gateName, m)
+	if err != nil {
+		return err
+	}
+
+	declared := []string{}
+	for _, project := range d.Get("projects").(*schema.Set).List() {
+		declared = append(declared, project.(string))
+	}
+
+	for _, project := range declared {
+		if slices.Contains(selected, project) {
+			continue
+		}
+		if err := selectQualityGateProject(gateName, project, m); err != nil {
+			return fmt.Errorf("synchronizeQualityGateProjects: Failed to select project '%s': %+v", project, err)
+		}
+	}
+
+	toDeselect := []string{}
+	if d.Get("authoritative").(bool) {
+		for _, project := range selected {
+			if !slices.Contains(declared, project) {
+				toDeselect = append(toDeselect, project)
+			}
+		}
+	} else {
+		// Only deselect the projects that were previously managed by this resource
+		old, _ := d.GetChange("projects")
+		for _, project := range old.(*schema.Set).List() {
+			if !slices.Contains(declared, project.(string)) && slices.Contains(selected, project.(string)) {
+				toDeselect = append(toDeselect, project.(string))
+			}
+		}
+	}
+
+	for _, project := range toDeselect {
+		if err := deselectQualityGateProject(gateName, project, m); err != nil {
+			return fmt.Errorf("synchronizeQualityGateProjects: Failed to deselect project '%s': %+v", project, err)
+		}
+	}
+	return nil
+}
+
+// readQualityGateProjectsFromApi returns the keys of every project explicitly associated to the quality gate
+func readQualityGateProjectsFromApi(gateName string, m interface{}) ([]string, error) {
+	projects := []string{}
+	page := 1
+	pageSize := 500
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"gateName": []string{gateName},
+			"selected": []string{"selected"},
+			"p":        []string{strconv.Itoa(page)},
+			"ps":       []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readQualityGateProjectsFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		projectsResponse := GetQualityGateProjects{}
+		if err := json.NewDecoder(resp.Body).Decode(&projectsResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readQualityGateProjectsFromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		for _, project := range projectsResponse.Results {
+			if project.Selected {
+				projects = append(projects, project.Key)
+			}
+		}
+
+		if !projectsResponse.More || len(projectsResponse.Results) == 0 {
+			return projects, nil
+		}
+		page++
+	}
+}
+
+func selectQualityGateProject(gateName string, projectKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/select"
+	sonarQubeURL.RawQuery = url.Values{
+		"gateName":   []string{gateName},
+		"projectKey": []string{projectKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"selectQualityGateProject",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func deselectQualityGateProject(gateName string, projectKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/deselect"
+	sonarQubeURL.RawQuery = url.Values{
+		"gateName":   []string{gateName},
+		"projectKey": []string{projectKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deselectQualityGateProject",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
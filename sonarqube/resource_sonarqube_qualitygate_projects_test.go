@@ -0,0 +1,82 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualitygate_projects", &resource.Sweeper{
+		Name: "sonarqube_qualitygate_projects",
+		F:    testSweepSonarqubeQualitygateProjectsSweeper,
+	})
+}
+
+func testSweepSonarqubeQualitygateProjectsSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualitygateProjectsConfig(rnd string, projects string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[1]s"
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "50"
+			}
+		}
+
+		resource "sonarqube_project" "%[1]s-1" {
+			name       = "%[1]s-1"
+			project    = "%[1]s-1"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project" "%[1]s-2" {
+			name       = "%[1]s-2"
+			project    = "%[1]s-2"
+			visibility = "public"
+		}
+
+		resource "sonarqube_qualitygate_projects" "%[1]s" {
+			gatename      = sonarqube_qualitygate.%[1]s.name
+			authoritative = true
+			projects      = [%[2]s]
+		}`, rnd, projects)
+}
+
+func TestAccSonarqubeQualitygateProjects(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate_projects." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualitygateProjectsConfig(rnd, fmt.Sprintf("sonarqube_project.%[1]s-1.project, sonarqube_project.%[1]s-2.project", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", rnd),
+					resource.TestCheckResourceAttr(name, "projects.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualitygateProjectsConfig(rnd, fmt.Sprintf("sonarqube_project.%[1]s-2.project", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "projects.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "projects.*", rnd+"-2"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authoritative"},
+			},
+		},
+	})
+}
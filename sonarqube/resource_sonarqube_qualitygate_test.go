@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -110,18 +112,160 @@ func TestAccSonarqubeQualitygateConditions(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "TestAccSonarqubeQualitygateConditions"),
 					resource.TestCheckResourceAttr(name, "condition.#", strconv.Itoa(expectedConditions)),
-					resource.TestCheckResourceAttr(name, "condition.0.metric", "new_coverage"),
-					resource.TestCheckResourceAttr(name, "condition.0.op", "LT"),
-					resource.TestCheckResourceAttr(name, "condition.0.threshold", "50"),
-					resource.TestCheckResourceAttr(name, "condition.1.metric", "reliability_rating"),
-					resource.TestCheckResourceAttr(name, "condition.1.op", "GT"),
-					resource.TestCheckResourceAttr(name, "condition.1.threshold", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "condition.*", map[string]string{
+						"metric":    "new_coverage",
+						"op":        "LT",
+						"threshold": "50",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "condition.*", map[string]string{
+						"metric":    "reliability_rating",
+						"op":        "GT",
+						"threshold": "2",
+					}),
 				),
 			},
 		},
 	})
 }
 
+func testAccSonarqubeQualitygateCaycConfig(rnd string, name string, threshold string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name                   = "%[2]s"
+			require_cayc_compliant = true
+
+			condition {
+				metric    = "new_violations"
+				op        = "GT"
+				threshold = "0"
+			}
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "%[3]s"
+			}
+
+			condition {
+				metric    = "new_duplicated_lines_density"
+				op        = "GT"
+				threshold = "3"
+			}
+
+			condition {
+				metric    = "new_security_hotspots_reviewed"
+				op        = "LT"
+				threshold = "100"
+			}
+		}`, rnd, name, threshold)
+}
+
+// A gate with the "Clean as You Code" conditions should report itself as compliant
+func TestAccSonarqubeQualitygateCaycStatus(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					// The "Clean as You Code" conditions used here were introduced in SonarQube 10.0
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.0")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping Clean as You Code test - not supported before SonarQube 10.0")
+						}
+					}
+				},
+				Config: testAccSonarqubeQualitygateCaycConfig(rnd, "testAccSonarqubeQualitygateCaycStatus", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "require_cayc_compliant", "true"),
+					resource.TestCheckResourceAttr(name, "cayc_status", "compliant"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualitygateConditionConfig(rnd string, name string, metric string, op string, threshold string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[2]s"
+
+			condition {
+				metric    = "%[3]s"
+				op        = "%[4]s"
+				threshold = "%[5]s"
+			}
+		}`, rnd, name, metric, op, threshold)
+}
+
+// Invalid conditions should be rejected at plan time
+func TestAccSonarqubeQualitygateInvalidConditions(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualitygateConditionConfig(rnd, "testAccSonarqubeQualitygateInvalid", "not_a_metric", "LT", "50"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("metric 'not_a_metric' does not exist"),
+			},
+			{
+				Config:      testAccSonarqubeQualitygateConditionConfig(rnd, "testAccSonarqubeQualitygateInvalid", "new_security_hotspots", "GT", "0"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot be used in a quality gate condition"),
+			},
+			{
+				Config:      testAccSonarqubeQualitygateConditionConfig(rnd, "testAccSonarqubeQualitygateInvalid", "reliability_rating", "GT", "6"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("ratings must be between 1 \\(A\\) and 5 \\(E\\)"),
+			},
+			{
+				Config:      testAccSonarqubeQualitygateConditionConfig(rnd, "testAccSonarqubeQualitygateInvalid", "new_coverage", "EQ", "50"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected .* to be one of"),
+			},
+		},
+	})
+}
+
+// Conditions are identified by their metric, so a metric can only be used once
+func TestAccSonarqubeQualitygateDuplicateConditionMetric(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "testAccSonarqubeQualitygateDuplicateMetric"
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "50"
+			}
+
+			condition {
+				metric    = "new_coverage"
+				op        = "LT"
+				threshold = "80"
+			}
+		}`, rnd),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("metric 'new_coverage' is used by more than one condition"),
+			},
+		},
+	})
+}
+
 func testAccSonarqubeQualitygateChangeDefaultConfig(rnd string, name string, firstIsDefault bool, threshold2 string) string {
 	return fmt.Sprintf(`
 		resource "sonarqube_qualitygate" "%[1]s-1" {
@@ -182,7 +326,10 @@ func TestAccSonarqubeQualitygateChangeDefault(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(gate1, "is_default", "false"),
 					resource.TestCheckResourceAttr(gate2, "is_default", "true"),
-					resource.TestCheckResourceAttr(gate2, "condition.0.threshold", "20"),
+					resource.TestCheckTypeSetElemNestedAttrs(gate2, "condition.*", map[string]string{
+						"metric":    "new_coverage",
+						"threshold": "20",
+					}),
 				),
 			},
 		},
@@ -44,6 +44,8 @@ type GetQualityProfile struct {
 	ActiveRuleCount           int                      `json:"activeRuleCount"`
 	ActiveDeprecatedRuleCount int                      `json:"activeDeprecatedRuleCount"`
 	IsDefault                 bool                     `json:"isDefault"`
+	ParentKey                 string                   `json:"parentKey"`
+	ParentName                string                   `json:"parentName"`
 	RuleUpdatedAt             string                   `json:"ruleUpdatedAt"`
 	LastUsed                  string                   `json:"lastUsed"`
 	Actions                   GetQualityProfileActions `json:"actions"`
@@ -58,12 +60,20 @@ type GetQualityProfileActions struct {
 	AssociateProjects bool `json:"associateProjects"`
 }
 
+// CopyQualityProfileResponse for unmarshalling response body of api/qualityprofiles/copy
+type CopyQualityProfileResponse struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityProfile() *schema.Resource {
 	return &schema.Resource{
 		Description: "Provides a Sonarqube Quality Profile resource. This can be used to create and manage Sonarqube Quality Profiles.",
 		Create:      resourceSonarqubeQualityProfileCreate,
 		Read:        resourceSonarqubeQualityProfileRead,
+		Update:      resourceSonarqubeQualityProfileUpdate,
 		Delete:      resourceSonarqubeQualityProfileDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileImport,
@@ -74,8 +84,7 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the Quality Profile to create. Maximum length 100",
+				Description: "The name of the Quality Profile to create. Maximum length 100. Changing this renames the Quality Profile.",
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringLenBetween(0, 100),
 				),
@@ -94,21 +103,32 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 			"is_default": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "When set to true this will make the added Quality Profile default",
+				Description: "When set to true this will make the added Quality Profile default. A default Quality Profile cannot be unset in place: set `is_default` on the Quality Profile that should become the default instead.",
 				Default:     false,
-				ForceNew:    true,
 			},
 			"parent": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "When a parent is provided the quality profile will inherit it's rules",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"copy_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of an existing Quality Profile of the same language to copy the rules from.",
 			},
 		},
 	}
 }
 
 func resourceSonarqubeQualityProfileCreate(d *schema.ResourceData, m interface{}) error {
+	if _, ok := d.GetOk("copy_from"); ok {
+		return resourceSonarqubeQualityProfileCopy(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/create"
 
@@ -182,6 +202,7 @@ func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{})
 			errs = append(errs, d.Set("language", value.Language))
 			errs = append(errs, d.Set("key", value.Key))
 			errs = append(errs, d.Set("is_default", value.IsDefault))
+			errs = append(errs, d.Set("parent", value.ParentName))
 			return errors.Join(errs...)
 		}
 	}
@@ -189,6 +210,183 @@ func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{})
 	return fmt.Errorf("resourceSonarqubeQualityProfileRead: Failed to find project: %+v", d.Id())
 }
 
+// resourceSonarqubeQualityProfileCopy creates the Quality Profile as a copy of the profile in copy_from
+func resourceSonarqubeQualityProfileCopy(d *schema.ResourceData, m interface{}) error {
+	source, err := readQualityProfileByNameFromApi(d.Get("language").(string), d.Get("copy_from").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileCopy: Failed to find the quality profile to copy: %+v", err)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/copy"
+	sonarQubeURL.RawQuery = url.Values{
+		"fromKey": []string{source.Key},
+		"toName":  []string{d.Get("name").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileCopy",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	copyResponse := CopyQualityProfileResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&copyResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileCopy: Failed to decode json into struct: %+v", err)
+	}
+
+	if d.Get("is_default").(bool) {
+		err := setDefaultQualityProfile(d, m, true)
+		if err != nil {
+			return err
+		}
+	}
+	// A copy does not inherit from any profile, only set the parent when one is declared
+	if _, ok := d.GetOk("parent"); ok {
+		err = setParentQualityProfile(d, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(copyResponse.Key)
+	return resourceSonarqubeQualityProfileRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("name") {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/rename"
+		sonarQubeURL.RawQuery = url.Values{
+			"key":  []string{d.Id()},
+			"name": []string{d.Get("name").(string)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"resourceSonarqubeQualityProfileUpdate",
+		)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to rename quality profile: %+v", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	if d.HasChange("parent") {
+		if err := setParentQualityProfile(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to change the parent of the quality profile: %+v", err)
+		}
+	}
+
+	if d.HasChange("is_default") {
+		if d.Get("is_default").(bool) {
+			if err := setDefaultQualityProfile(d, m, true); err != nil {
+				return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to change the default quality profile: %+v", err)
+			}
+		} else {
+			// There always is a default profile, so it can only be moved by making another profile the default
+			profile, err := readQualityProfileByNameFromApi(d.Get("language").(string), d.Get("name").(string), m)
+			if err != nil {
+				return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to read the quality profile: %+v", err)
+			}
+			if profile.IsDefault {
+				return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: quality profile '%s' is still the default for language '%s'. Set is_default on the Quality Profile that should become the default instead", profile.Name, profile.Language)
+			}
+		}
+	}
+
+	return resourceSonarqubeQualityProfileRead(d, m)
+}
+
+// readQualityProfileByNameFromApi returns the quality profile with the name and language
+func readQualityProfileByNameFromApi(language string, name string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language":       []string{language},
+		"qualityProfile": []string{name},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readQualityProfileByNameFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readQualityProfileByNameFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.Name == name {
+			return &value, nil
+		}
+	}
+	return nil, fmt.Errorf("readQualityProfileByNameFromApi: quality profile '%s' does not exist for language '%s'", name, language)
+}
+
+// readBuiltInQualityProfileFromApi returns the built-in quality profile of the language, preferring "Sonar way" when there are several
+func readBuiltInQualityProfileFromApi(language string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language": []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readBuiltInQualityProfileFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readBuiltInQualityProfileFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	var builtIn *GetQualityProfile
+	for i, value := range getQualityProfileResponse.Profiles {
+		if !value.IsBuiltIn {
+			continue
+		}
+		if builtIn == nil || value.Name == "Sonar way" {
+			builtIn = &getQualityProfileResponse.Profiles[i]
+		}
+	}
+	if builtIn == nil {
+		return nil, fmt.Errorf("readBuiltInQualityProfileFromApi: there is no built-in quality profile for language '%s'", language)
+	}
+	return builtIn, nil
+}
+
 func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/delete"
@@ -237,9 +435,14 @@ func setDefaultQualityProfile(d *schema.ResourceData, m interface{}, setDefault
 			"language":       []string{d.Get("language").(string)},
 		}.Encode()
 	} else {
+		// Fall back to the built-in profile of the language, as there must always be a default
+		builtIn, err := readBuiltInQualityProfileFromApi(d.Get("language").(string), m)
+		if err != nil {
+			return err
+		}
 		sonarQubeURL.RawQuery = url.Values{
-			"qualityProfile": []string{"Sonar way"},
-			"language":       []string{d.Get("language").(string)},
+			"qualityProfile": []string{builtIn.Name},
+			"language":       []string{builtIn.Language},
 		}.Encode()
 	}
 
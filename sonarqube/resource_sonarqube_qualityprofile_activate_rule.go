@@ -7,15 +7,17 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type Actives struct {
-	QProfile string   `json:"qProfile"`
-	Inherit  string   `json:"inherit"`
-	Severity string   `json:"severity"`
-	Params   []Params `json:"params"`
+	QProfile string       `json:"qProfile"`
+	Inherit  string       `json:"inherit"`
+	Severity string       `json:"severity"`
+	Params   []Params     `json:"params"`
+	Impacts  []RuleImpact `json:"impacts,omitempty"`
 }
 
 type GetActiveRules struct {
@@ -34,6 +36,18 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"impacts": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: `Override of the impact severities of the rule, as a map of software quality to severity. Ignored if parameter reset is true. The declared impacts are read back, so changes made outside of Terraform are detected. Requires SonarQube 10.8 or later.
+  - Possible software qualities - ` + strings.Join(ruleSoftwareQualities, ", ") + `
+  - Possible severities - ` + strings.Join(ruleImpactSeverities, ", "),
+				ValidateDiagFunc: validateRuleImpacts,
+			},
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -86,17 +100,32 @@ func resourceSonarqubeQualityProfileRule() *schema.Resource {
 	}
 }
 
+func checkRuleImpactsActivationSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.8")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for overriding the impacts of an activated rule is %s", minimumVersion)
+	}
+	return nil
+}
+
 func resourceSonarqubeQualityProfileRuleCreate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
 
-	sonarQubeURL.RawQuery = url.Values{
+	rawQuery := url.Values{
 		"key":      []string{d.Get("key").(string)},
 		"params":   []string{d.Get("params").(string)},
 		"reset":    []string{d.Get("reset").(string)},
 		"rule":     []string{d.Get("rule").(string)},
 		"severity": []string{d.Get("severity").(string)},
-	}.Encode()
+	}
+	if impacts, ok := d.GetOk("impacts"); ok {
+		if err := checkRuleImpactsActivationSupport(m.(*ProviderConfiguration)); err != nil {
+			return err
+		}
+		rawQuery.Add("impacts", formatRuleImpacts(impacts.(map[string]interface{})))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -164,6 +193,15 @@ func resourceSonarqubeQualityProfileRuleRead(d *schema.ResourceData, m interface
 
 	if d.Id() == activeRuleReadResponse.Rule.RuleKey {
 		d.SetId(activeRuleReadResponse.Rule.RuleKey)
+
+		// Only the declared impacts are read back, the others keep the severity of the rule
+		if impacts, ok := d.GetOk("impacts"); ok {
+			for _, active := range activeRuleReadResponse.Actives {
+				if active.QProfile == d.Get("key").(string) {
+					return d.Set("impacts", flattenDeclaredRuleImpacts(impacts.(map[string]interface{}), active.Impacts))
+				}
+			}
+		}
 		return nil
 	}
 
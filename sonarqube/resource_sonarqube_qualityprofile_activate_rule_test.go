@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -71,3 +72,46 @@ func TestAccSonarqubeQualityprofileActivateRuleBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeQualityprofileActivateRuleImpactsConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_activate_rule" "%[1]s" {
+			key  = sonarqube_qualityprofile.%[1]s.key
+			rule = "java:S1135"
+			impacts = {
+				MAINTAINABILITY = "BLOCKER"
+			}
+		}`, rnd)
+}
+
+func TestAccSonarqubeQualityprofileActivateRuleImpacts(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_activate_rule." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.8")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping impacts override test - not supported before SonarQube 10.8")
+						}
+					}
+				},
+				Config: testAccSonarqubeQualityprofileActivateRuleImpactsConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule", "java:S1135"),
+					resource.TestCheckResourceAttr(name, "impacts.MAINTAINABILITY", "BLOCKER"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,348 @@
+package sonarqube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityProfileBackup for unmarshalling the XML backup of a quality profile. Only the fields used to detect drift are kept.
+type QualityProfileBackup struct {
+	XMLName  xml.Name                   `xml:"profile"`
+	Name     string                     `xml:"name"`
+	Language string                     `xml:"language"`
+	Rules    []QualityProfileBackupRule `xml:"rules>rule"`
+}
+
+// QualityProfileBackupRule used in QualityProfileBackup
+type QualityProfileBackupRule struct {
+	RepositoryKey string                          `xml:"repositoryKey"`
+	Key           string                          `xml:"key"`
+	Priority      string                          `xml:"priority"`
+	Impacts       []QualityProfileBackupImpact    `xml:"impacts>impact"`
+	Parameters    []QualityProfileBackupParameter `xml:"parameters>parameter"`
+}
+
+// QualityProfileBackupImpact used in QualityProfileBackupRule
+type QualityProfileBackupImpact struct {
+	SoftwareQuality string `xml:"softwareQuality"`
+	Severity        string `xml:"severity"`
+}
+
+// QualityProfileBackupParameter used in QualityProfileBackupRule
+type QualityProfileBackupParameter struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// RestoreQualityProfileResponse for unmarshalling response body of api/qualityprofiles/restore
+type RestoreQualityProfileResponse struct {
+	Profile       QualityProfile `json:"profile"`
+	RuleSuccesses int            `json:"ruleSuccesses"`
+	RuleFailures  int            `json:"ruleFailures"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileRestore() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Quality Profile Restore resource. This can be used to create or update a Quality Profile from an XML backup, as exported by ` + "`api/qualityprofiles/backup`" + ` or the ` + "`sonarqube_qualityprofile_backup`" + ` data source.
+
+Drift is detected by comparing the name, language and rules (with their severity, impacts and parameters) of the backup with a backup of the Quality Profile.`,
+		Create: resourceSonarqubeQualityProfileRestoreCreate,
+		Read:   resourceSonarqubeQualityProfileRestoreRead,
+		Update: resourceSonarqubeQualityProfileRestoreUpdate,
+		Delete: resourceSonarqubeQualityProfileRestoreDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileRestoreImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityProfileRestoreResource(d)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"backup": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The XML backup of the Quality Profile, e.g. loaded with `file()`. Changing the name or language of the profile in the backup forces a new resource to be created.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := parseQualityProfileBackup(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q is not a valid quality profile backup: %+v", key, err))
+					}
+					return
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return qualityProfileBackupsEqual(old, new)
+				},
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Sonarqube Quality Profile",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Quality Profile, as defined in the backup.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The language of the Quality Profile, as defined in the backup.",
+			},
+		},
+	}
+}
+
+// Restoring a backup with another name or language creates another profile, so the resource must be replaced
+func validateQualityProfileRestoreResource(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("backup") || !d.NewValueKnown("backup") {
+		return nil
+	}
+
+	backup, err := parseQualityProfileBackup(d.Get("backup").(string))
+	if err != nil {
+		return fmt.Errorf("validateQualityProfileRestoreResource: %+v", err)
+	}
+	if backup.Name != d.Get("name").(string) || backup.Language != d.Get("language").(string) {
+		return d.ForceNew("backup")
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRestoreCreate(d *schema.ResourceData, m interface{}) error {
+	restoreResponse, err := restoreQualityProfileBackup(d.Get("backup").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: Failed to restore the quality profile: %+v", err)
+	}
+
+	d.SetId(restoreResponse.Profile.Key)
+	errs := []error{}
+	errs = append(errs, d.Set("name", restoreResponse.Profile.Name))
+	errs = append(errs, d.Set("language", restoreResponse.Profile.Language))
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	if restoreResponse.RuleFailures > 0 {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: %d rules of the backup could not be restored", restoreResponse.RuleFailures)
+	}
+	return resourceSonarqubeQualityProfileRestoreRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRestoreRead(d *schema.ResourceData, m interface{}) error {
+	backup, found, err := readQualityProfileBackupFromApi(d.Get("language").(string), d.Get("name").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreRead: Failed to backup the quality profile: %+v", err)
+	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("key", d.Id()))
+	// Keep the declared backup when it matches the profile, so that only real changes show up in the plan
+	if !qualityProfileBackupsEqual(d.Get("backup").(string), backup) {
+		errs = append(errs, d.Set("backup", backup))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeQualityProfileRestoreUpdate(d *schema.ResourceData, m interface{}) error {
+	restoreResponse, err := restoreQualityProfileBackup(d.Get("backup").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreUpdate: Failed to restore the quality profile: %+v", err)
+	}
+	if restoreResponse.RuleFailures > 0 {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreUpdate: %d rules of the backup could not be restored", restoreResponse.RuleFailures)
+	}
+	return resourceSonarqubeQualityProfileRestoreRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRestoreDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/delete"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{d.Get("name").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeQualityProfileRestoreDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreDelete: Failed to delete quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRestoreImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Parse ID: language/name. The name may contain slashes, the language cannot
+	language, name, found := strings.Cut(d.Id(), "/")
+	if !found || language == "" || name == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRestoreImport: invalid import ID format '%s', expected 'language/name'", d.Id())
+	}
+
+	qualityProfile, err := readQualityProfileByNameFromApi(language, name, m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileRestoreImport: %+v", err)
+	}
+
+	d.SetId(qualityProfile.Key)
+	errs := []error{}
+	errs = append(errs, d.Set("name", qualityProfile.Name))
+	errs = append(errs, d.Set("language", qualityProfile.Language))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeQualityProfileRestoreRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// restoreQualityProfileBackup uploads the backup, creating the profile or replacing the rules of the existing profile with the same name and language
+func restoreQualityProfileBackup(backup string, m interface{}) (*RestoreQualityProfileResponse, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/restore"
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("backup", "backup.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(part, backup); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	resp, err := httpRequestHelperWithBody(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		body.Bytes(),
+		writer.FormDataContentType(),
+		http.StatusOK,
+		"restoreQualityProfileBackup",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	restoreResponse := RestoreQualityProfileResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&restoreResponse)
+	if err != nil {
+		return nil, fmt.Errorf("restoreQualityProfileBackup: Failed to decode json into struct: %+v", err)
+	}
+
+	return &restoreResponse, nil
+}
+
+// readQualityProfileBackupFromApi returns the XML backup of the quality profile, and whether the profile exists
+func readQualityProfileBackupFromApi(language string, name string, m interface{}) (string, bool, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/backup"
+	sonarQubeURL.RawQuery = url.Values{
+		"language":       []string{language},
+		"qualityProfile": []string{name},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readQualityProfileBackupFromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	backup, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, fmt.Errorf("readQualityProfileBackupFromApi: Failed to read the backup: %+v", err)
+	}
+	return string(backup), true, nil
+}
+
+func parseQualityProfileBackup(backup string) (*QualityProfileBackup, error) {
+	profile := QualityProfileBackup{}
+	if err := xml.Unmarshal([]byte(backup), &profile); err != nil {
+		return nil, err
+	}
+	if profile.Name == "" || profile.Language == "" {
+		return nil, fmt.Errorf("the name and language of the profile are required")
+	}
+	return &profile, nil
+}
+
+// normalizeQualityProfileBackup sorts the rules and parameters of the backup, and drops everything that is not used to detect drift
+func normalizeQualityProfileBackup(backup string) (string, error) {
+	profile, err := parseQualityProfileBackup(backup)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rule := range profile.Rules {
+		sort.Slice(rule.Impacts, func(i, j int) bool {
+			return rule.Impacts[i].SoftwareQuality < rule.Impacts[j].SoftwareQuality
+		})
+		sort.Slice(rule.Parameters, func(i, j int) bool {
+			return rule.Parameters[i].Key < rule.Parameters[j].Key
+		})
+	}
+	sort.Slice(profile.Rules, func(i, j int) bool {
+		if profile.Rules[i].RepositoryKey != profile.Rules[j].RepositoryKey {
+			return profile.Rules[i].RepositoryKey < profile.Rules[j].RepositoryKey
+		}
+		return profile.Rules[i].Key < profile.Rules[j].Key
+	})
+
+	normalized, err := xml.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+func qualityProfileBackupsEqual(a string, b string) bool {
+	normalizedA, err := normalizeQualityProfileBackup(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := normalizeQualityProfileBackup(b)
+	if err != nil {
+		return false
+	}
+	return normalizedA == normalizedB
+}
@@ -0,0 +1,134 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_restore", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_restore",
+		F:    testSweepSonarqubeQualityprofileRestoreSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityprofileRestoreSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityprofileBackup(name string, severity string) string {
+	return fmt.Sprintf(`<?xml version='1.0' encoding='UTF-8'?>
+<profile>
+  <name>%[1]s</name>
+  <language>java</language>
+  <rules>
+    <rule>
+      <repositoryKey>java</repositoryKey>
+      <key>S138</key>
+      <type>CODE_SMELL</type>
+      <priority>MAJOR</priority>
+      <parameters>
+        <parameter>
+          <key>max</key>
+          <value>100</value>
+        </parameter>
+      </parameters>
+    </rule>
+    <rule>
+      <repositoryKey>java</repositoryKey>
+      <key>S1135</key>
+      <type>CODE_SMELL</type>
+      <priority>%[2]s</priority>
+      <parameters/>
+    </rule>
+  </rules>
+</profile>`, name, severity)
+}
+
+func testAccSonarqubeQualityprofileRestoreConfig(rnd string, backup string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile_restore" "%[1]s" {
+			backup = <<-EOT
+%[2]s
+EOT
+		}
+
+		data "sonarqube_qualityprofile_backup" "%[1]s" {
+			name     = sonarqube_qualityprofile_restore.%[1]s.name
+			language = sonarqube_qualityprofile_restore.%[1]s.language
+		}`, rnd, backup)
+}
+
+func TestAccSonarqubeQualityprofileRestore(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_restore." + rnd
+	dataSourceName := "data.sonarqube_qualityprofile_backup." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityprofileRestoreConfig(rnd, testAccSonarqubeQualityprofileBackup(rnd, "INFO")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "language", "java"),
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestMatchResourceAttr(dataSourceName, "backup", regexp.MustCompile(regexp.QuoteMeta("<key>S1135</key>"))),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityprofileRestoreConfig(rnd, testAccSonarqubeQualityprofileBackup(rnd, "BLOCKER")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestMatchResourceAttr(dataSourceName, "backup", regexp.MustCompile(regexp.QuoteMeta("<priority>BLOCKER</priority>"))),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "java/" + rnd,
+				ImportStateVerify: true,
+				// The imported backup is the export of the server, which only matches the declared backup once normalized
+				ImportStateVerifyIgnore: []string{"backup"},
+			},
+		},
+	})
+}
+
+func TestQualityProfileBackupsEqual(t *testing.T) {
+	backup := testAccSonarqubeQualityprofileBackup("profile", "INFO")
+	// Same rules in another order and without formatting
+	reordered := `<profile><name>profile</name><language>java</language><rules>` +
+		`<rule><repositoryKey>java</repositoryKey><key>S1135</key><priority>INFO</priority></rule>` +
+		`<rule><repositoryKey>java</repositoryKey><key>S138</key><priority>MAJOR</priority><parameters><parameter><key>max</key><value>100</value></parameter></parameters></rule>` +
+		`</rules></profile>`
+
+	if !qualityProfileBackupsEqual(backup, reordered) {
+		t.Errorf("expected backups to be equal")
+	}
+	if qualityProfileBackupsEqual(backup, testAccSonarqubeQualityprofileBackup("profile", "BLOCKER")) {
+		t.Errorf("expected backups with different severities to differ")
+	}
+	if qualityProfileBackupsEqual(backup, strings.Replace(backup, "<value>100</value>", "<value>50</value>", 1)) {
+		t.Errorf("expected backups with different parameters to differ")
+	}
+	withImpacts := strings.Replace(backup, "<priority>INFO</priority>", "<priority>INFO</priority><impacts><impact><softwareQuality>RELIABILITY</softwareQuality><severity>LOW</severity></impact><impact><softwareQuality>MAINTAINABILITY</softwareQuality><severity>HIGH</severity></impact></impacts>", 1)
+	reorderedImpacts := strings.Replace(backup, "<priority>INFO</priority>", "<priority>INFO</priority><impacts><impact><softwareQuality>MAINTAINABILITY</softwareQuality><severity>HIGH</severity></impact><impact><softwareQuality>RELIABILITY</softwareQuality><severity>LOW</severity></impact></impacts>", 1)
+	if !qualityProfileBackupsEqual(withImpacts, reorderedImpacts) {
+		t.Errorf("expected backups with impacts in another order to be equal")
+	}
+	if qualityProfileBackupsEqual(withImpacts, strings.Replace(withImpacts, "<severity>HIGH</severity>", "<severity>BLOCKER</severity>", 1)) {
+		t.Errorf("expected backups with different impacts to differ")
+	}
+	if qualityProfileBackupsEqual(backup, withImpacts) {
+		t.Errorf("expected backups with and without impacts to differ")
+	}
+	if qualityProfileBackupsEqual(backup, "not xml") {
+		t.Errorf("expected invalid backups to differ")
+	}
+}
@@ -0,0 +1,546 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ActiveRuleParam used in ActiveRule
+type ActiveRuleParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ActiveRule used in GetQualityProfileActiveRules
+type ActiveRule struct {
+	QProfile string            `json:"qProfile"`
+	Inherit  string            `json:"inherit"`
+	Severity string            `json:"severity"`
+	Params   []ActiveRuleParam `json:"params"`
+	Impacts  []RuleImpact      `json:"impacts,omitempty"`
+}
+
+// GetQualityProfileActiveRules for unmarshalling response body of api/rules/search with the actives field
+type GetQualityProfileActiveRules struct {
+	Rules   []Rule                  `json:"rules"`
+	Actives map[string][]ActiveRule `json:"actives"`
+	Total   int                     `json:"total"`
+	P       int                     `json:"p"`
+	PS      int                     `json:"ps"`
+}
+
+// validateQualityProfileRuleParams rejects the separators of the params list sent to api/qualityprofiles/activate_rule
+var validateQualityProfileRuleParams = validation.AllDiag(
+	validation.MapKeyMatch(
+		regexp.MustCompile(`^[^;=]+$`),
+		"parameter keys cannot contain ';' or '='",
+	),
+	validation.MapValueMatch(
+		regexp.MustCompile(`^[^;=]*$`),
+		"parameter values cannot contain ';' or '='",
+	),
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileRules() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Quality Profile Rules resource. This can be used to manage the activated rules of a Quality Profile as a whole.
+
+All active rules of the Quality Profile are read with a single (paginated) search, and only the differences are applied.
+This resource should not be combined with ` + "`sonarqube_qualityprofile_activate_rule`" + ` resources for the same Quality Profile.`,
+		Create: resourceSonarqubeQualityProfileRulesCreate,
+		Read:   resourceSonarqubeQualityProfileRulesRead,
+		Update: resourceSonarqubeQualityProfileRulesUpdate,
+		Delete: resourceSonarqubeQualityProfileRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileRulesImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityProfileRuleKeysUnique(d)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality Profile key. Can be obtained through api/qualityprofiles/search. Changing this forces a new resource to be created.",
+			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, active rules which are not declared in a `rule` block are deactivated. Rules inherited from a parent profile are never deactivated. Defaults to `false`.",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         hashQualityProfileRule,
+				Description: "The rules to activate. Rules are identified by their key, so changing the `severity`, `params` or `impacts` of a rule updates its activation in place. Removing them resets the activation to the values of the parent profile or the rule defaults.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule key",
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: `Severity. When not set, the default severity of the rule is used.
+  - Possible values - INFO, MINOR, MAJOR, CRITICAL, BLOCKER`,
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringInSlice(
+									[]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"},
+									false,
+								),
+							),
+						},
+						"params": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Parameters of the rule. Parameters which are not set use their default value. Keys and values cannot contain `;` or `=`.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							ValidateDiagFunc: validateQualityProfileRuleParams,
+						},
+						"impacts": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: `Override of the impact severities of the rule, as a map of software quality to severity. Impacts which are not set keep the severity of the rule. Requires SonarQube 10.8 or later.
+  - Possible software qualities - ` + strings.Join(ruleSoftwareQualities, ", ") + `
+  - Possible severities - ` + strings.Join(ruleImpactSeverities, ", "),
+							ValidateDiagFunc: validateRuleImpacts,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hashQualityProfileRule identifies an activation by its rule key, so that changing the severity or parameters updates it in place
+func hashQualityProfileRule(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["key"].(string))
+}
+
+// Rules are hashed on their key, so rules sharing a key would silently collapse into one.
+// The configuration is checked instead of the set to catch them.
+func validateQualityProfileRuleKeysUnique(d *schema.ResourceDiff) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	rules := rawConfig.GetAttr("rule")
+	if rules.IsNull() || !rules.IsKnown() {
+		return nil
+	}
+
+	keys := map[string]bool{}
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		key := rule.GetAttr("key")
+		if key.IsNull() || !key.IsKnown() {
+			continue
+		}
+		if keys[key.AsString()] {
+			return fmt.Errorf("validateQualityProfileRuleKeysUnique: rule '%s' is declared more than once", key.AsString())
+		}
+		keys[key.AsString()] = true
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRulesCreate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeQualityProfileRules(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesCreate: Failed to synchronize rules: %+v", err)
+	}
+
+	d.SetId(d.Get("key").(string))
+	return resourceSonarqubeQualityProfileRulesRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRulesRead(d *schema.ResourceData, m interface{}) error {
+	activeRules, err := readQualityProfileActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesRead: Failed to read the active rules: %+v", err)
+	}
+
+	rules := flattenQualityProfileActiveRules(d.Get("rule").(*schema.Set).List(), activeRules, d.Get("authoritative").(bool))
+
+	errs := []error{}
+	errs = append(errs, d.Set("key", d.Id()))
+	errs = append(errs, d.Set("rule", rules))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeQualityProfileRulesUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeQualityProfileRules(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesUpdate: Failed to synchronize rules: %+v", err)
+	}
+	return resourceSonarqubeQualityProfileRulesRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRulesDelete(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+	activeRules, err := readQualityProfileActiveRulesFromApi(profileKey, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: Failed to read the active rules: %+v", err)
+	}
+
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		ruleKey := r.(map[string]interface{})["key"].(string)
+		if activeRule, ok := activeRules[ruleKey]; !ok || activeRule.Inherit != "NONE" {
+			continue
+		}
+		if err := deactivateQualityProfileRule(profileKey, ruleKey, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileRulesDelete: Failed to deactivate rule '%s': %+v", ruleKey, err)
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRulesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import every rule activated on the profile itself, since there is no configuration to compare with yet
+	if err := d.Set("authoritative", true); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeQualityProfileRulesRead(d, m); err != nil {
+		return nil, err
+	}
+	if err := d.Set("authoritative", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// synchronizeQualityProfileRules activates the declared rules that differ from the API and deactivates the rules that should no longer be active
+func synchronizeQualityProfileRules(d *schema.ResourceData, m interface{}) error {
+	profileKey := d.Get("key").(string)
+	activeRules, err := readQualityProfileActiveRulesFromApi(profileKey, m)
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("rule")
+	oldRules := map[string]map[string]interface{}{}
+	for _, r := range old.(*schema.Set).List() {
+		oldRules[r.(map[string]interface{})["key"].(string)] = r.(map[string]interface{})
+	}
+
+	declaredKeys := []string{}
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		rule := r.(map[string]interface{})
+		ruleKey := rule["key"].(string)
+		declaredKeys = append(declaredKeys, ruleKey)
+
+		activeRule, active := activeRules[ruleKey]
+		// Values which are no longer declared are only reverted by resetting the activation
+		if oldRule, ok := oldRules[ruleKey]; ok && active && checkQualityProfileRuleFieldsRemoved(oldRule, rule) {
+			if err := resetQualityProfileRule(profileKey, ruleKey, m); err != nil {
+				return fmt.Errorf("synchronizeQualityProfileRules: Failed to reset rule '%s': %+v", ruleKey, err)
+			}
+			if !qualityProfileRuleFieldsDeclared(rule) {
+				continue
+			}
+		} else if active && !checkQualityProfileRuleDiff(rule, activeRule) {
+			continue
+		}
+		if err := activateQualityProfileRule(profileKey, rule, m); err != nil {
+			return fmt.Errorf("synchronizeQualityProfileRules: Failed to activate rule '%s': %+v", ruleKey, err)
+		}
+	}
+
+	toDeactivate := []string{}
+	if d.Get("authoritative").(bool) {
+		for ruleKey, activeRule := range activeRules {
+			if activeRule.Inherit == "NONE" && !slices.Contains(declaredKeys, ruleKey) {
+				toDeactivate = append(toDeactivate, ruleKey)
+			}
+		}
+	} else {
+		// Only deactivate the rules that were previously managed by this resource
+		for _, r := range old.(*schema.Set).List() {
+			ruleKey := r.(map[string]interface{})["key"].(string)
+			if activeRule, ok := activeRules[ruleKey]; ok && activeRule.Inherit == "NONE" && !slices.Contains(declaredKeys, ruleKey) {
+				toDeactivate = append(toDeactivate, ruleKey)
+			}
+		}
+	}
+	sort.Strings(toDeactivate)
+
+	for _, ruleKey := range toDeactivate {
+		if err := deactivateQualityProfileRule(profileKey, ruleKey, m); err != nil {
+			return fmt.Errorf("synchronizeQualityProfileRules: Failed to deactivate rule '%s': %+v", ruleKey, err)
+		}
+	}
+	return nil
+}
+
+// checkQualityProfileRuleDiff returns true when the declared severity, parameters or impacts differ from the activation
+func checkQualityProfileRuleDiff(rule map[string]interface{}, activeRule ActiveRule) bool {
+	if severity := rule["severity"].(string); severity != "" && severity != activeRule.Severity {
+		return true
+	}
+	for softwareQuality, severity := range rule["impacts"].(map[string]interface{}) {
+		index := slices.IndexFunc(activeRule.Impacts, func(impact RuleImpact) bool { return impact.SoftwareQuality == softwareQuality })
+		if index == -1 || activeRule.Impacts[index].Severity != severity.(string) {
+			return true
+		}
+	}
+	for key, value := range rule["params"].(map[string]interface{}) {
+		index := slices.IndexFunc(activeRule.Params, func(param ActiveRuleParam) bool { return param.Key == key })
+		if index == -1 || activeRule.Params[index].Value != value.(string) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkQualityProfileRuleFieldsRemoved returns true when the severity, a parameter or an impact declared before is no longer declared
+func checkQualityProfileRuleFieldsRemoved(oldRule map[string]interface{}, rule map[string]interface{}) bool {
+	if oldRule["severity"].(string) != "" && rule["severity"].(string) == "" {
+		return true
+	}
+	for _, field := range []string{"params", "impacts"} {
+		for key := range oldRule[field].(map[string]interface{}) {
+			if _, ok := rule[field].(map[string]interface{})[key]; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// qualityProfileRuleFieldsDeclared returns true when the rule declares a severity, parameters or impacts
+func qualityProfileRuleFieldsDeclared(rule map[string]interface{}) bool {
+	return rule["severity"].(string) != "" || len(rule["params"].(map[string]interface{})) > 0 || len(rule["impacts"].(map[string]interface{})) > 0
+}
+
+// flattenQualityProfileActiveRules returns the activation of every declared rule, keeping the declared values when there is no difference.
+// When includeUndeclared is true, rules which are activated on the profile itself but not declared are returned as well.
+func flattenQualityProfileActiveRules(declared []interface{}, activeRules map[string]ActiveRule, includeUndeclared bool) []interface{} {
+	rules := []interface{}{}
+	declaredKeys := []string{}
+
+	for _, r := range declared {
+		rule := r.(map[string]interface{})
+		ruleKey := rule["key"].(string)
+		declaredKeys = append(declaredKeys, ruleKey)
+
+		activeRule, ok := activeRules[ruleKey]
+		if !ok {
+			continue
+		}
+		if !checkQualityProfileRuleDiff(rule, activeRule) {
+			rules = append(rules, rule)
+			continue
+		}
+
+		// Only report the parameters that are declared, the others use their default value
+		params := map[string]interface{}{}
+		for key := range rule["params"].(map[string]interface{}) {
+			for _, param := range activeRule.Params {
+				if param.Key == key {
+					params[key] = param.Value
+				}
+			}
+		}
+		severity := ""
+		if rule["severity"].(string) != "" {
+			severity = activeRule.Severity
+		}
+		rules = append(rules, map[string]interface{}{
+			"key":      ruleKey,
+			"severity": severity,
+			"params":   params,
+			"impacts":  flattenDeclaredRuleImpacts(rule["impacts"].(map[string]interface{}), activeRule.Impacts),
+		})
+	}
+
+	if includeUndeclared {
+		for ruleKey, activeRule := range activeRules {
+			if activeRule.Inherit != "NONE" || slices.Contains(declaredKeys, ruleKey) {
+				continue
+			}
+			params := map[string]interface{}{}
+			for _, param := range activeRule.Params {
+				params[param.Key] = param.Value
+			}
+			// Impacts are not imported, as the API cannot tell the overridden impacts from those of the rule
+			rules = append(rules, map[string]interface{}{
+				"key":      ruleKey,
+				"severity": activeRule.Severity,
+				"params":   params,
+			})
+		}
+	}
+
+	return rules
+}
+
+// readQualityProfileActiveRulesFromApi returns the activation of every rule active on the quality profile, by rule key
+func readQualityProfileActiveRulesFromApi(profileKey string, m interface{}) (map[string]ActiveRule, error) {
+	activeRules := map[string]ActiveRule{}
+	page := 1
+	pageSize := 500
+	count := 0
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"qprofile":   []string{profileKey},
+			"activation": []string{"true"},
+			"f":          []string{"actives"},
+			"p":          []string{strconv.Itoa(page)},
+			"ps":         []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readQualityProfileActiveRulesFromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		activeRulesResponse := GetQualityProfileActiveRules{}
+		if err := json.NewDecoder(resp.Body).Decode(&activeRulesResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("readQualityProfileActiveRulesFromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		for ruleKey, actives := range activeRulesResponse.Actives {
+			for _, active := range actives {
+				if active.QProfile == profileKey {
+					activeRules[ruleKey] = active
+				}
+			}
+		}
+
+		count += len(activeRulesResponse.Rules)
+		if count >= activeRulesResponse.Total || len(activeRulesResponse.Rules) == 0 {
+			break
+		}
+		page++
+	}
+
+	return activeRules, nil
+}
+
+func activateQualityProfileRule(profileKey string, rule map[string]interface{}, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+
+	rawQuery := url.Values{
+		"key":  []string{profileKey},
+		"rule": []string{rule["key"].(string)},
+	}
+	if severity := rule["severity"].(string); severity != "" {
+		rawQuery.Add("severity", severity)
+	}
+	if params := rule["params"].(map[string]interface{}); len(params) > 0 {
+		// Parameters are passed as a semi-colon list of key=value
+		paramList := []string{}
+		for key, value := range params {
+			paramList = append(paramList, fmt.Sprintf("%s=%s", key, value.(string)))
+		}
+		sort.Strings(paramList)
+		rawQuery.Add("params", strings.Join(paramList, ";"))
+	}
+	if impacts := rule["impacts"].(map[string]interface{}); len(impacts) > 0 {
+		if err := checkRuleImpactsActivationSupport(m.(*ProviderConfiguration)); err != nil {
+			return err
+		}
+		rawQuery.Add("impacts", formatRuleImpacts(impacts))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"activateQualityProfileRule",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// resetQualityProfileRule reverts the severity, parameters and impacts of an active rule to those of the parent profile or the rule defaults
+func resetQualityProfileRule(profileKey string, ruleKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":   []string{profileKey},
+		"rule":  []string{ruleKey},
+		"reset": []string{"true"},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resetQualityProfileRule",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func deactivateQualityProfileRule(profileKey string, ruleKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/deactivate_rule"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":  []string{profileKey},
+		"rule": []string{ruleKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deactivateQualityProfileRule",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
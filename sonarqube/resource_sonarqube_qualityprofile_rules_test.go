@@ -0,0 +1,282 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_rules", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_rules",
+		F:    testSweepSonarqubeQualityprofileRulesSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityprofileRulesSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityprofileRulesConfig(rnd string, severity string, max string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key           = sonarqube_qualityprofile.%[1]s.key
+			authoritative = true
+
+			rule {
+				key      = "java:S1135"
+				severity = "%[2]s"
+			}
+
+			rule {
+				key = "java:S138"
+				params = {
+					max = "%[3]s"
+				}
+			}
+		}`, rnd, severity, max)
+}
+
+func testAccSonarqubeQualityprofileRulesDefaultsConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key           = sonarqube_qualityprofile.%[1]s.key
+			authoritative = true
+
+			rule {
+				key = "java:S1135"
+			}
+
+			rule {
+				key = "java:S138"
+			}
+		}`, rnd)
+}
+
+// testAccCheckQualityProfileRuleActivation checks the severity and a parameter of an active rule on the server
+func testAccCheckQualityProfileRuleActivation(name string, ruleKey string, severity string, paramKey string, paramValue string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		activeRules, err := readQualityProfileActiveRulesFromApi(rs.Primary.ID, testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+		activeRule, ok := activeRules[ruleKey]
+		if !ok {
+			return fmt.Errorf("rule %s is not active", ruleKey)
+		}
+		if severity != "" && activeRule.Severity != severity {
+			return fmt.Errorf("expected severity %s for rule %s, got %s", severity, ruleKey, activeRule.Severity)
+		}
+		if paramKey != "" {
+			index := slices.IndexFunc(activeRule.Params, func(param ActiveRuleParam) bool { return param.Key == paramKey })
+			if index == -1 || activeRule.Params[index].Value != paramValue {
+				return fmt.Errorf("expected parameter %s=%s for rule %s, got %v", paramKey, paramValue, ruleKey, activeRule.Params)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccSonarqubeQualityprofileRules(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityprofileRulesConfig(rnd, "INFO", "100"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "INFO",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S138",
+						"params.max": "100",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityprofileRulesConfig(rnd, "BLOCKER", "50"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1135",
+						"severity": "BLOCKER",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S138",
+						"params.max": "50",
+					}),
+				),
+			},
+			{
+				// Removing the severity and parameters reverts them to the rule defaults
+				Config: testAccSonarqubeQualityprofileRulesDefaultsConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					testAccCheckQualityProfileRuleActivation(name, "java:S1135", "INFO", "", ""),
+					testAccCheckQualityProfileRuleActivation(name, "java:S138", "", "max", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityprofileRulesInvalidConfig(rnd string, secondKey string, max string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			rule {
+				key      = "java:S138"
+				severity = "INFO"
+			}
+
+			rule {
+				key = "%[2]s"
+				params = {
+					max = "%[3]s"
+				}
+			}
+		}`, rnd, secondKey, max)
+}
+
+// Invalid rules should be rejected at plan time
+func TestAccSonarqubeQualityprofileRulesInvalid(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualityprofileRulesInvalidConfig(rnd, "java:S138", "100"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("rule 'java:S138' is declared more than once"),
+			},
+			{
+				Config:      testAccSonarqubeQualityprofileRulesInvalidConfig(rnd, "java:S1135", "100;format=x"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("parameter values cannot contain ';' or '='"),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityprofileRulesImpactsConfig(rnd string, severity string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_qualityprofile_rules" "%[1]s" {
+			key = sonarqube_qualityprofile.%[1]s.key
+
+			rule {
+				key = "java:S1135"
+				impacts = {
+					MAINTAINABILITY = "%[2]s"
+				}
+			}
+		}`, rnd, severity)
+}
+
+func TestAccSonarqubeQualityprofileRulesImpacts(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						if err := checkRuleImpactsActivationSupport(testAccProvider.Meta().(*ProviderConfiguration)); err != nil {
+							t.Skipf("Skipping impacts override test - %s", err)
+						}
+					}
+				},
+				Config: testAccSonarqubeQualityprofileRulesImpactsConfig(rnd, "BLOCKER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":                     "java:S1135",
+						"impacts.MAINTAINABILITY": "BLOCKER",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityprofileRulesImpactsConfig(rnd, "LOW"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":                     "java:S1135",
+						"impacts.MAINTAINABILITY": "LOW",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenQualityProfileActiveRulesImpacts(t *testing.T) {
+	declared := []interface{}{
+		map[string]interface{}{
+			"key":      "java:S1135",
+			"severity": "",
+			"params":   map[string]interface{}{},
+			"impacts":  map[string]interface{}{"MAINTAINABILITY": "BLOCKER"},
+		},
+	}
+	activeRules := map[string]ActiveRule{
+		"java:S1135": {
+			Inherit:  "NONE",
+			Severity: "INFO",
+			Impacts: []RuleImpact{
+				{SoftwareQuality: "MAINTAINABILITY", Severity: "LOW"},
+				{SoftwareQuality: "RELIABILITY", Severity: "LOW"},
+			},
+		},
+	}
+
+	if !checkQualityProfileRuleDiff(declared[0].(map[string]interface{}), activeRules["java:S1135"]) {
+		t.Errorf("Expected a diff when the declared impact differs from the activation")
+	}
+
+	rules := flattenQualityProfileActiveRules(declared, activeRules, false)
+	if len(rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(rules))
+	}
+	// Only the declared software quality is reported, with the severity of the activation
+	impacts := rules[0].(map[string]interface{})["impacts"].(map[string]interface{})
+	if len(impacts) != 1 || impacts["MAINTAINABILITY"] != "LOW" {
+		t.Errorf("Expected impacts map[MAINTAINABILITY:LOW], got %v", impacts)
+	}
+}
@@ -2,6 +2,7 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -54,3 +55,101 @@ func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeQualityProfileUpdateConfig(rnd string, name string, isDefault bool, parent string, parentIsDefault bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s-parent" {
+			name       = "%[1]s-parent"
+			language   = "js"
+			is_default = %[5]t
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name       = "%[2]s"
+			language   = "js"
+			is_default = %[3]t
+			parent     = "%[4]s"
+			depends_on = [sonarqube_qualityprofile.%[1]s-parent]
+		}`, rnd, name, isDefault, parent, parentIsDefault)
+}
+
+// Renaming a profile and changing its parent or default status should update it in place
+func TestAccSonarqubeQualityProfileUpdate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+	var key string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileUpdate", false, "", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileUpdate"),
+					resource.TestCheckResourceAttr(name, "parent", ""),
+					resource.TestCheckResourceAttrWith(name, "key", func(value string) error {
+						key = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileRenamed", true, rnd+"-parent", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileRenamed"),
+					resource.TestCheckResourceAttr(name, "parent", rnd+"-parent"),
+					resource.TestCheckResourceAttr(name, "is_default", "true"),
+					resource.TestCheckResourceAttrWith(name, "key", func(value string) error {
+						if value != key {
+							return fmt.Errorf("expected the quality profile to be updated in place, but its key changed from %s to %s", key, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// The default profile can only be moved to another profile
+				Config:      testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileRenamed", false, "", false),
+				ExpectError: regexp.MustCompile("is still the default for language 'js'"),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileUpdateConfig(rnd, "testAccSonarqubeQualityProfileRenamed", false, "", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", ""),
+					resource.TestCheckResourceAttr(name, "is_default", "false"),
+					resource.TestCheckResourceAttr(name+"-parent", "is_default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileCopyConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name      = "%[1]s"
+			language  = "js"
+			copy_from = "Sonar way"
+		}`, rnd)
+}
+
+func TestAccSonarqubeQualityProfileCopy(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileCopyConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "copy_from", "Sonar way"),
+					resource.TestCheckResourceAttrSet(name, "key"),
+				),
+			},
+		},
+	})
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -33,6 +36,16 @@ type Rule struct {
 	IsExternal  bool     `json:"isExternal"`
 	Type        string   `json:"type"`
 	Params      []Params `json:"params,omitempty"`
+	// Clean Code taxonomy, available from SonarQube 10.2
+	CleanCodeAttribute         string       `json:"cleanCodeAttribute,omitempty"`
+	CleanCodeAttributeCategory string       `json:"cleanCodeAttributeCategory,omitempty"`
+	Impacts                    []RuleImpact `json:"impacts,omitempty"`
+}
+
+// RuleImpact is the severity of a rule for one software quality
+type RuleImpact struct {
+	SoftwareQuality string `json:"softwareQuality"`
+	Severity        string `json:"severity"`
 }
 
 type Params struct {
@@ -53,6 +66,27 @@ type CreateRuleResponse struct {
 	Rule Rule `json:"rule"`
 }
 
+var (
+	ruleCleanCodeAttributes = []string{
+		"CONVENTIONAL", "FORMATTED", "IDENTIFIABLE", "CLEAR", "COMPLETE", "EFFICIENT", "LOGICAL",
+		"DISTINCT", "FOCUSED", "MODULAR", "TESTED", "LAWFUL", "RESPECTFUL", "TRUSTWORTHY",
+	}
+	ruleSoftwareQualities = []string{"MAINTAINABILITY", "RELIABILITY", "SECURITY"}
+	ruleImpactSeverities  = []string{"INFO", "LOW", "MEDIUM", "HIGH", "BLOCKER"}
+)
+
+// validateRuleImpacts checks that impacts map a software quality to an impact severity
+var validateRuleImpacts = validation.AllDiag(
+	validation.MapKeyMatch(
+		regexp.MustCompile("^("+strings.Join(ruleSoftwareQualities, "|")+")$"),
+		"software quality must be one of "+strings.Join(ruleSoftwareQualities, ", "),
+	),
+	validation.MapValueMatch(
+		regexp.MustCompile("^("+strings.Join(ruleImpactSeverities, "|")+")$"),
+		"impact severity must be one of "+strings.Join(ruleImpactSeverities, ", "),
+	),
+)
+
 func resourceSonarqubeRule() *schema.Resource {
 	return &schema.Resource{
 		Description: "Provides a Sonarqube Rules resource. This can be used to manage Sonarqube rules.",
@@ -154,15 +188,52 @@ func resourceSonarqubeRule() *schema.Resource {
 					),
 				),
 			},
+			"clean_code_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: `Clean Code attribute of the rule. Requires SonarQube 10.4 or later to be set. Changing this forces a new resource to be created.
+  - Possible values - ` + strings.Join(ruleCleanCodeAttributes, ", "),
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(ruleCleanCodeAttributes, false),
+				),
+			},
+			"clean_code_attribute_category": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Category of the Clean Code attribute of the rule: `ADAPTABLE`, `CONSISTENT`, `INTENTIONAL` or `RESPONSIBLE`.",
+			},
+			"impacts": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: `Impacts of the rule, as a map of software quality to severity. Requires SonarQube 10.4 or later to be set. Changing this forces a new resource to be created.
+  - Possible software qualities - ` + strings.Join(ruleSoftwareQualities, ", ") + `
+  - Possible severities - ` + strings.Join(ruleImpactSeverities, ", ") + ` (` + "`INFO`" + ` and ` + "`BLOCKER`" + ` require SonarQube 10.8 or later)`,
+				ValidateDiagFunc: validateRuleImpacts,
+			},
 		},
 	}
 }
 
+func checkRuleCleanCodeSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.4")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for setting the Clean Code attribute and impacts of a rule is %s", minimumVersion)
+	}
+	return nil
+}
+
 func resourceSonarqubeRuleCreate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/create"
 
-	sonarQubeURL.RawQuery = url.Values{
+	rawQuery := url.Values{
 		"customKey":           []string{d.Get("custom_key").(string)},
 		"markdownDescription": []string{d.Get("markdown_description").(string)},
 		"name":                []string{d.Get("name").(string)},
@@ -172,7 +243,23 @@ func resourceSonarqubeRuleCreate(d *schema.ResourceData, m interface{}) error {
 		"status":              []string{d.Get("status").(string)},
 		"templateKey":         []string{d.Get("template_key").(string)},
 		"type":                []string{d.Get("type").(string)},
-	}.Encode()
+	}
+
+	// The Clean Code attribute and impacts are only sent when declared, as they are computed by the server otherwise
+	cleanCodeAttribute, hasCleanCodeAttribute := d.GetOk("clean_code_attribute")
+	impacts, hasImpacts := d.GetOk("impacts")
+	if hasCleanCodeAttribute || hasImpacts {
+		if err := checkRuleCleanCodeSupport(m.(*ProviderConfiguration)); err != nil {
+			return err
+		}
+	}
+	if hasCleanCodeAttribute {
+		rawQuery.Add("cleanCodeAttribute", cleanCodeAttribute.(string))
+	}
+	if hasImpacts {
+		rawQuery.Add("impacts", formatRuleImpacts(impacts.(map[string]interface{})))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -230,6 +317,9 @@ func resourceSonarqubeRuleRead(d *schema.ResourceData, m interface{}) error {
 			errs = append(errs, d.Set("template_key", value.TemplateKey))
 			errs = append(errs, d.Set("status", value.Status))
 			errs = append(errs, d.Set("type", value.Type))
+			errs = append(errs, d.Set("clean_code_attribute", value.CleanCodeAttribute))
+			errs = append(errs, d.Set("clean_code_attribute_category", value.CleanCodeAttributeCategory))
+			errs = append(errs, d.Set("impacts", flattenRuleImpacts(value.Impacts)))
 			return errors.Join(errs...)
 		}
 	}
@@ -291,3 +381,33 @@ func resourceSonarqubeRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	return resourceSonarqubeRuleRead(d, m)
 }
+
+// formatRuleImpacts formats impacts as expected by the API: SOFTWARE_QUALITY=SEVERITY, separated by semicolons
+func formatRuleImpacts(impacts map[string]interface{}) string {
+	formatted := []string{}
+	for softwareQuality, severity := range impacts {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", softwareQuality, severity.(string)))
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, ";")
+}
+
+// flattenDeclaredRuleImpacts returns the severities of the declared software qualities only, since the API also reports the impacts which are not overridden
+func flattenDeclaredRuleImpacts(declared map[string]interface{}, impacts []RuleImpact) map[string]interface{} {
+	flattened := map[string]interface{}{}
+	for _, impact := range impacts {
+		if _, ok := declared[impact.SoftwareQuality]; ok {
+			flattened[impact.SoftwareQuality] = impact.Severity
+		}
+	}
+	return flattened
+}
+
+// flattenRuleImpacts converts the impacts returned by the API into a map of software quality to severity
+func flattenRuleImpacts(impacts []RuleImpact) map[string]interface{} {
+	flattened := map[string]interface{}{}
+	for _, impact := range impacts {
+		flattened[impact.SoftwareQuality] = impact.Severity
+	}
+	return flattened
+}
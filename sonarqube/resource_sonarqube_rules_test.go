@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -69,3 +70,48 @@ func TestAccSonarqubeRuleBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeRuleCleanCodeConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_rule" "%[1]s" {
+			custom_key           = "%[1]s"
+			markdown_description = "My rule"
+			name                 = "%[1]s"
+			template_key         = "xml:XPathCheck"
+			clean_code_attribute = "CONVENTIONAL"
+			impacts = {
+				MAINTAINABILITY = "HIGH"
+				SECURITY        = "LOW"
+			}
+		}`, rnd)
+}
+
+func TestAccSonarqubeRuleCleanCode(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.4")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping Clean Code taxonomy test - not supported before SonarQube 10.4")
+						}
+					}
+				},
+				Config: testAccSonarqubeRuleCleanCodeConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "clean_code_attribute", "CONVENTIONAL"),
+					resource.TestCheckResourceAttr(name, "clean_code_attribute_category", "CONSISTENT"),
+					resource.TestCheckResourceAttr(name, "impacts.%", "2"),
+					resource.TestCheckResourceAttr(name, "impacts.MAINTAINABILITY", "HIGH"),
+					resource.TestCheckResourceAttr(name, "impacts.SECURITY", "LOW"),
+				),
+			},
+		},
+	})
+}
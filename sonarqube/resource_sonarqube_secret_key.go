@@ -0,0 +1,106 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GenerateSecretKeyResponse for unmarshalling response body of api/settings/generate_secret_key
+type GenerateSecretKeyResponse struct {
+	SecretKey string `json:"secretKey"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeSecretKey() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Secret Key resource. This can be used to generate the secret key used to encrypt setting values.
+
+SonarQube does not install the generated key: it must be stored in the file configured by ` + "`sonar.secretKeyPath`" + ` on the server before values can be encrypted.
+Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubeSecretKeyCreate,
+		Read:   resourceSonarqubeSecretKeyRead,
+		Delete: resourceSonarqubeSecretKeyDelete,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated secret key.",
+			},
+			"secret_key_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a secret key is installed on the server.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeSecretKeyCreate(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/generate_secret_key"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeSecretKeyCreate",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	secretKeyResponse := GenerateSecretKeyResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&secretKeyResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeSecretKeyCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	// The ID must not be derived from the secret key, as it is not sensitive
+	d.SetId(id.UniqueId())
+	if err := d.Set("secret_key", secretKeyResponse.SecretKey); err != nil {
+		return err
+	}
+	return resourceSonarqubeSecretKeyRead(d, m)
+}
+
+func resourceSonarqubeSecretKeyRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/check_secret_key"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeSecretKeyRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	checkSecretKeyResponse := CheckSecretKeyResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&checkSecretKeyResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeSecretKeyRead: Failed to decode json into struct: %+v", err)
+	}
+
+	return d.Set("secret_key_available", checkSecretKeyResponse.SecretKeyAvailable)
+}
+
+func resourceSonarqubeSecretKeyDelete(d *schema.ResourceData, m interface{}) error {
+	// The secret key lives in a file on the server, there is nothing to delete through the API
+	return nil
+}
@@ -0,0 +1,32 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeSecretKeyConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_secret_key" "%[1]s" {}`, rnd)
+}
+
+func TestAccSonarqubeSecretKey(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_secret_key." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeSecretKeyConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "secret_key"),
+					resource.TestCheckResourceAttrSet(name, "secret_key_available"),
+				),
+			},
+		},
+	})
+}
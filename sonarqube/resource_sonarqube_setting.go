@@ -1,15 +1,19 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,6 +30,16 @@ type GetSettings struct {
 	SetSecuredSettings []string  `json:"setSecuredSettings"`
 }
 
+// EncryptSettingResponse for unmarshalling response body of api/settings/encrypt
+type EncryptSettingResponse struct {
+	EncryptedValue string `json:"encryptedValue"`
+}
+
+// CheckSecretKeyResponse for unmarshalling response body of api/settings/check_secret_key
+type CheckSecretKeyResponse struct {
+	SecretKeyAvailable bool `json:"secretKeyAvailable"`
+}
+
 func (a Setting) ToMap() map[string]interface{} {
 	obj := make(map[string]interface{})
 
@@ -42,7 +56,7 @@ func (a Setting) ToMap() map[string]interface{} {
 
 func resourceSonarqubeSettings() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Settings resource. This can be used to manage Sonarqube settings.",
+		Description: "Provides a Sonarqube Settings resource. This can be used to manage global Sonarqube settings, or settings of a single project, portfolio or branch.",
 		Create:      resourceSonarqubeSettingsCreate,
 		Read:        resourceSonarqubeSettingsRead,
 		Update:      resourceSonarqubeSettingsUpdate,
@@ -50,24 +64,66 @@ func resourceSonarqubeSettings() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeSettingsImporter,
 		},
+		// A setting that is only inherited from the parent scope needs to be set explicitly
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateSettingSecuredValue(d)
+			},
+			customdiff.IfValue("inherited",
+				func(_ context.Context, value, meta interface{}) bool {
+					return value.(bool)
+				},
+				func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+					return d.SetNew("inherited", false)
+				},
+			),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Setting key",
 			},
+			"component": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Key of the component (project, portfolio or application) to set the setting on. When unset, the setting is managed globally. Do not combine with the `setting` block of the `sonarqube_project` resource for the same key. Changing this forces a new resource to be created.",
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"component"},
+				Description:  "Branch of the component to set the setting on. Setting this also requires setting the `component` argument. Changing this forces a new resource to be created.",
+			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Setting value. To reset a value, please use the reset web service.",
-				ExactlyOneOf: []string{"value", "values", "field_values"},
+				Description:  "Setting value. To reset a value, please use the reset web service. Secured settings (with a key ending in `.secured`) must use `secured_value` instead.",
+				ExactlyOneOf: []string{"value", "values", "field_values", "secured_value"},
+			},
+			"secured_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Setting value of a secured setting (with a key ending in `.secured`), or of a setting stored `encrypted`. The value is write-only: it cannot be read back, so changes made outside of Terraform are not detected.",
+				ExactlyOneOf: []string{"value", "values", "field_values", "secured_value"},
+			},
+			"encrypted": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"value", "values", "field_values"},
+				Description:   "When set to true, `secured_value` is encrypted with the server secret key (api/settings/encrypt) before it is stored. The secret key must be available on the server, see the `sonarqube_secret_key` resource. Defaults to `false`.",
 			},
 			"values": {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Description:  "Setting multi values for the supplied key",
-				ExactlyOneOf: []string{"value", "values", "field_values"},
+				ExactlyOneOf: []string{"value", "values", "field_values", "secured_value"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -76,12 +132,17 @@ func resourceSonarqubeSettings() *schema.Resource {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Description:  "Setting field values for the supplied key",
-				ExactlyOneOf: []string{"value", "values", "field_values"},
+				ExactlyOneOf: []string{"value", "values", "field_values", "secured_value"},
 				Elem: &schema.Schema{
 					Type: schema.TypeMap,
 					Elem: schema.TypeString,
 				},
 			},
+			"inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the value is not set on this scope but comes from the parent scope (the global setting or the default value). An inherited setting is set explicitly on the next apply.",
+			},
 		},
 	}
 }
@@ -89,7 +150,11 @@ func resourceSonarqubeSettings() *schema.Resource {
 func resourceSonarqubeSettingsCreate(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
-	sonarQubeURL.RawQuery = getCreateOrUpdateQueryRawQuery([]string{d.Get("key").(string)}, d)
+	rawQuery, err := getCreateOrUpdateQueryRawQuery([]string{d.Get("key").(string)}, d, m)
+	if err != nil {
+		return err
+	}
+	sonarQubeURL.RawQuery = rawQuery
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -103,16 +168,21 @@ func resourceSonarqubeSettingsCreate(d *schema.ResourceData, m interface{}) erro
 	}
 	defer resp.Body.Close()
 
-	d.SetId(d.Get("key").(string))
+	d.SetId(createSettingId(d.Get("component").(string), d.Get("branch").(string), d.Get("key").(string)))
 	return resourceSonarqubeSettingsRead(d, m)
 }
 
 func resourceSonarqubeSettingsRead(d *schema.ResourceData, m interface{}) error {
+	key := d.Get("key").(string)
+	component := d.Get("component").(string)
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/values"
-	sonarQubeURL.RawQuery = url.Values{
-		"keys": []string{d.Id()},
-	}.Encode()
+	rawQuery := url.Values{
+		"keys": []string{key},
+	}
+	addSettingScopeQuery(rawQuery, d)
+	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -132,26 +202,49 @@ func resourceSonarqubeSettingsRead(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("resourceSonarqubeSettingsRead: Failed to decode json into struct: %+v", err)
 	}
 
+	// The API never returns the value of secured settings, only whether they are set
+	if isSecuredSetting(key) {
+		if slices.Contains(settingReadResponse.SetSecuredSettings, key) {
+			d.SetId(createSettingId(component, d.Get("branch").(string), key))
+			return d.Set("inherited", false)
+		}
+		d.SetId("")
+		return nil
+	}
+
 	for _, value := range settingReadResponse.Setting {
-		if d.Id() == value.Key {
+		if key == value.Key {
 			errs := []error{}
 			errs = append(errs, d.Set("key", value.Key))
-			errs = append(errs, d.Set("value", value.Value))
-			errs = append(errs, d.Set("values", value.Values))
-			errs = append(errs, d.Set("field_values", value.FieldValues))
-			d.SetId(value.Key)
+			// The API returns the encrypted value, so we keep the declared secured value
+			if !d.Get("encrypted").(bool) {
+				errs = append(errs, d.Set("value", value.Value))
+				errs = append(errs, d.Set("values", value.Values))
+				errs = append(errs, d.Set("field_values", value.FieldValues))
+			}
+			// A value inherited from the parent scope (or a default value) is not actually set
+			errs = append(errs, d.Set("inherited", value.Inherited))
+			d.SetId(createSettingId(component, d.Get("branch").(string), value.Key))
 			return errors.Join(errs...)
 		}
 	}
+
+	if component != "" {
+		// Setting has no value on the component nor in any parent scope
+		d.SetId("")
+		return nil
+	}
 	return fmt.Errorf("resourceSonarqubeSettingsRead: Failed to find setting: %+v", d.Id())
 }
 
 func resourceSonarqubeSettingsDelete(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/reset"
-	sonarQubeURL.RawQuery = url.Values{
-		"keys": []string{d.Id()},
-	}.Encode()
+	rawQuery := url.Values{
+		"keys": []string{d.Get("key").(string)},
+	}
+	addSettingScopeQuery(rawQuery, d)
+	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -169,12 +262,33 @@ func resourceSonarqubeSettingsDelete(d *schema.ResourceData, m interface{}) erro
 }
 
 func resourceSonarqubeSettingsImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := d.Set("key", d.Id()); err != nil {
+	// Parse ID: key, component/key or component/branch/key
+	// Setting keys and component keys never contain a slash, but branch names can.
+	parts := strings.Split(d.Id(), "/")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("resourceSonarqubeSettingsImporter: invalid import ID format: %s", d.Id())
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("key", parts[len(parts)-1]))
+	if len(parts) > 1 {
+		errs = append(errs, d.Set("component", parts[0]))
+	}
+	if len(parts) > 2 {
+		errs = append(errs, d.Set("branch", strings.Join(parts[1:len(parts)-1], "/")))
+	}
+	if err := errors.Join(errs...); err != nil {
 		return nil, err
 	}
+
 	if err := resourceSonarqubeSettingsRead(d, m); err != nil {
 		return nil, err
 	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeSettingsImporter: setting %s is not set", d.Get("key").(string))
+	}
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -182,7 +296,11 @@ func resourceSonarqubeSettingsUpdate(d *schema.ResourceData, m interface{}) erro
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
 
-	sonarQubeURL.RawQuery = getCreateOrUpdateQueryRawQuery([]string{d.Id()}, d)
+	rawQuery, err := getCreateOrUpdateQueryRawQuery([]string{d.Get("key").(string)}, d, m)
+	if err != nil {
+		return err
+	}
+	sonarQubeURL.RawQuery = rawQuery
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -199,15 +317,46 @@ func resourceSonarqubeSettingsUpdate(d *schema.ResourceData, m interface{}) erro
 	return resourceSonarqubeSettingsRead(d, m)
 }
 
-func getCreateOrUpdateQueryRawQuery(key []string, d *schema.ResourceData) string {
+// createSettingId returns the ID of a setting: the key for global settings, component/key or component/branch/key otherwise
+func createSettingId(component string, branch string, key string) string {
+	if component == "" {
+		return key
+	}
+	if branch == "" {
+		return component + "/" + key
+	}
+	return component + "/" + branch + "/" + key
+}
+
+// addSettingScopeQuery adds the component and branch of the setting to the query, if any
+func addSettingScopeQuery(rawQuery url.Values, d *schema.ResourceData) {
+	if component, ok := d.GetOk("component"); ok {
+		rawQuery.Add("component", component.(string))
+		if branch, ok := d.GetOk("branch"); ok {
+			rawQuery.Add("branch", branch.(string))
+		}
+	}
+}
+
+func getCreateOrUpdateQueryRawQuery(key []string, d *schema.ResourceData, m interface{}) (string, error) {
 	// build the base query
 	RawQuery := url.Values{
 		"key": key,
 	}
+	addSettingScopeQuery(RawQuery, d)
 	// Add in value/values/fieldValues as appropriate
 	// single value
 	if value, ok := d.GetOk("value"); ok {
 		RawQuery.Add("value", value.(string))
+	} else if securedValue, ok := d.GetOk("secured_value"); ok {
+		if d.Get("encrypted").(bool) {
+			encryptedValue, err := encryptSettingValue(securedValue.(string), m)
+			if err != nil {
+				return "", err
+			}
+			securedValue = encryptedValue
+		}
+		RawQuery.Add("value", securedValue.(string))
 	} else {
 		// array of strings
 		if values, ok := d.GetOk("values"); ok {
@@ -224,7 +373,80 @@ func getCreateOrUpdateQueryRawQuery(key []string, d *schema.ResourceData) string
 			}
 		}
 	}
-	return RawQuery.Encode()
+	return RawQuery.Encode(), nil
+}
+
+// validateSettingSecuredValue makes sure that the value of secured settings is only set through the sensitive secured_value
+func validateSettingSecuredValue(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("key") {
+		return nil
+	}
+	key := d.Get("key").(string)
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	if isSecuredSetting(key) && rawConfig.GetAttr("secured_value").IsNull() {
+		return fmt.Errorf("validateSettingSecuredValue: setting '%s' is secured, its value must be set with secured_value", key)
+	}
+	return nil
+}
+
+// isSecuredSetting returns whether the setting is write-only, which is the case for keys ending in .secured
+func isSecuredSetting(key string) bool {
+	return strings.HasSuffix(key, ".secured")
+}
+
+// encryptSettingValue encrypts a value with the secret key of the server, which has to be available
+func encryptSettingValue(value string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/check_secret_key"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"encryptSettingValue",
+	)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to check the secret key: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	checkSecretKeyResponse := CheckSecretKeyResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&checkSecretKeyResponse)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to decode json into struct: %+v", err)
+	}
+	if !checkSecretKeyResponse.SecretKeyAvailable {
+		return "", fmt.Errorf("encryptSettingValue: no secret key is available on the server. Generate one with api/settings/generate_secret_key (or the sonarqube_secret_key resource) and store it in the file configured by sonar.secretKeyPath")
+	}
+
+	sonarQubeURL.Path = strings.TrimSuffix(m.(*ProviderConfiguration).sonarQubeURL.Path, "/") + "/api/settings/encrypt"
+	sonarQubeURL.RawQuery = url.Values{
+		"value": []string{value},
+	}.Encode()
+
+	encryptResp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"encryptSettingValue",
+	)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to encrypt value: %+v", err)
+	}
+	defer encryptResp.Body.Close()
+
+	encryptSettingResponse := EncryptSettingResponse{}
+	err = json.NewDecoder(encryptResp.Body).Decode(&encryptSettingResponse)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to decode json into struct: %+v", err)
+	}
+
+	return encryptSettingResponse.EncryptedValue, nil
 }
 
 /* This content is used for settings parameter in multiple resources ('project', 'portfolio')  */
@@ -232,9 +454,20 @@ func getComponentSettings(component string, m interface{}) ([]Setting, error) {
 	if component == "" {
 		return []Setting{}, nil
 	}
+	settingReadResponse, err := getSettings(component, m)
+	if err != nil {
+		return nil, err
+	}
+	return settingReadResponse.Setting, nil
+}
+
+// getSettings returns all settings of the component, or all global settings when no component is given
+func getSettings(component string, m interface{}) (*GetSettings, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/values"
-	sonarQubeURL.RawQuery = url.Values{"component": []string{component}}.Encode()
+	if component != "" {
+		sonarQubeURL.RawQuery = url.Values{"component": []string{component}}.Encode()
+	}
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -254,16 +487,13 @@ func getComponentSettings(component string, m interface{}) ([]Setting, error) {
 		return nil, fmt.Errorf("getProjectSettings: Failed to decode json into struct: %+v", err)
 	}
 
-	settingsList := make([]Setting, 0)
-	// Filter settings by parameter inherited
-	settingsList = append(settingsList, settingReadResponse.Setting...)
-
-	// Make sure the order is always the same for when we are comparing lists of conditions
-	sort.Slice(settingsList, func(i, j int) bool {
-		return settingsList[i].Key < settingsList[j].Key
+	// Inherited values are kept, callers decide whether they count as set.
+	// Make sure the order is always the same for when we are comparing lists of settings
+	sort.Slice(settingReadResponse.Setting, func(i, j int) bool {
+		return settingReadResponse.Setting[i].Key < settingReadResponse.Setting[j].Key
 	})
 
-	return settingsList, nil
+	return &settingReadResponse, nil
 }
 
 func synchronizeSettings(d *schema.ResourceData, m interface{}) (bool, error) {
@@ -313,6 +543,10 @@ func synchronizeSettings(d *schema.ResourceData, m interface{}) (bool, error) {
 }
 
 func checkSettingDiff(a map[string]interface{}, b Setting) bool {
+	// An inherited value is not set on the component, even if it is equal to the declared value
+	if b.Inherited {
+		return true
+	}
 	if a["field_values"] != nil && len(a["field_values"].([]interface{})) > 0 {
 		// array of objects of key/value pairs
 		fieldValues := a["field_values"].([]interface{})
@@ -348,6 +582,12 @@ func getComponentSettingUrlEncode(setting map[string]interface{}) url.Values {
 		addedSetting = true
 	}
 
+	// Only the sonarqube_settings resource declares secured values
+	if setting["secured_value"] != nil && setting["secured_value"] != "" && !addedSetting {
+		raw.Add("value", setting["secured_value"].(string))
+		addedSetting = true
+	}
+
 	if setting["values"] != nil && !addedSetting {
 		// array of strings
 		for _, value := range setting["values"].([]interface{}) {
@@ -372,7 +612,9 @@ func setComponentSetting(component string, setting map[string]interface{}, m int
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
 	params := getComponentSettingUrlEncode(setting)
-	params.Add("component", component)
+	if component != "" {
+		params.Add("component", component)
+	}
 	sonarQubeURL.RawQuery = params.Encode()
 
 	_, err := httpRequestHelper(
@@ -411,20 +653,7 @@ func removeComponentSettings(component string, newSettings []interface{}, apiPro
 	}
 	// Delete not found
 	if len(toDelete) > 0 {
-		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/reset"
-		sonarQubeURL.RawQuery = url.Values{
-			"component": []string{component},
-			"keys":      []string{strings.Join(toDelete, ",")},
-		}.Encode()
-
-		_, err := httpRequestHelper(
-			m.(*ProviderConfiguration).httpClient,
-			"POST",
-			sonarQubeURL.String(),
-			http.StatusNoContent,
-			"deleteSetting",
-		)
+		err := resetSettings(component, toDelete, m)
 		if err != nil {
 			return fmt.Errorf("removeComponentSettings: Failed to delete setting %s: %+v", component, err)
 		}
@@ -432,3 +661,148 @@ func removeComponentSettings(component string, newSettings []interface{}, apiPro
 	}
 	return nil
 }
+
+// resetSettings resets the given keys of the component, or the global settings when no component is given
+func resetSettings(component string, keys []string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/reset"
+	rawQuery := url.Values{
+		"keys": []string{strings.Join(keys, ",")},
+	}
+	if component != "" {
+		rawQuery.Add("component", component)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deleteSetting",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// settingAttribute maps an attribute of a resource to the global setting backing it
+type settingAttribute struct {
+	Attribute string
+	Key       string
+	// The resource only exists while the setting is set
+	Required bool
+}
+
+// setSettingAttributes sets the global settings backing the attributes, or only those of the changed attributes when onlyChanges is true.
+// Settings of empty attributes are reset to their default value.
+func setSettingAttributes(attributes []settingAttribute, d *schema.ResourceData, onlyChanges bool, m interface{}) error {
+	changed := false
+	toReset := []string{}
+	for _, attribute := range attributes {
+		if onlyChanges && !d.HasChange(attribute.Attribute) {
+			continue
+		}
+
+		setting := map[string]interface{}{"key": attribute.Key}
+		switch value := d.Get(attribute.Attribute).(type) {
+		case string:
+			if value == "" {
+				toReset = append(toReset, attribute.Key)
+				continue
+			}
+			setting["value"] = value
+		case bool:
+			setting["value"] = strconv.FormatBool(value)
+		case *schema.Set:
+			if value.Len() == 0 {
+				toReset = append(toReset, attribute.Key)
+				continue
+			}
+			setting["values"] = value.List()
+		}
+
+		if err := setComponentSetting("", setting, m, &changed); err != nil {
+			return err
+		}
+	}
+
+	if len(toReset) > 0 {
+		if err := resetSettings("", toReset, m); err != nil {
+			return fmt.Errorf("setSettingAttributes: Failed to reset settings %v: %+v", toReset, err)
+		}
+	}
+	return nil
+}
+
+// readSettingAttributes sets the attributes from the global settings backing them, and returns whether every required setting is set
+func readSettingAttributes(attributes []settingAttribute, d *schema.ResourceData, m interface{}) (bool, error) {
+	apiSettings, err := getSettings("", m)
+	if err != nil {
+		return false, err
+	}
+
+	found := true
+	errs := []error{}
+	for _, attribute := range attributes {
+		// Secured settings are write-only, we can only check that they are still set
+		if isSecuredSetting(attribute.Key) {
+			if !slices.Contains(apiSettings.SetSecuredSettings, attribute.Key) {
+				found = found && !attribute.Required
+				errs = append(errs, d.Set(attribute.Attribute, ""))
+			}
+			continue
+		}
+
+		setting := Setting{}
+		index := slices.IndexFunc(apiSettings.Setting, func(apiSetting Setting) bool { return apiSetting.Key == attribute.Key })
+		if index != -1 {
+			setting = apiSettings.Setting[index]
+		} else {
+			found = found && !attribute.Required
+		}
+
+		switch d.Get(attribute.Attribute).(type) {
+		case string:
+			errs = append(errs, d.Set(attribute.Attribute, setting.Value))
+		case bool:
+			errs = append(errs, d.Set(attribute.Attribute, setting.Value == "true"))
+		case *schema.Set:
+			errs = append(errs, d.Set(attribute.Attribute, setting.Values))
+		}
+	}
+	return found, errors.Join(errs...)
+}
+
+// resetSettingAttributes resets the global settings backing the attributes
+func resetSettingAttributes(attributes []settingAttribute, m interface{}) error {
+	keys := []string{}
+	for _, attribute := range attributes {
+		keys = append(keys, attribute.Key)
+	}
+	return resetSettings("", keys, m)
+}
+
+// expandAuthenticationConfiguration returns the body of a v2 api request from the attributes mapped to each field,
+// with only the changed attributes when onlyChanges is true
+func expandAuthenticationConfiguration(fields map[string]string, d *schema.ResourceData, onlyChanges bool) map[string]interface{} {
+	body := map[string]interface{}{}
+	for field, attribute := range fields {
+		if onlyChanges && !d.HasChange(attribute) {
+			continue
+		}
+		if set, ok := d.Get(attribute).(*schema.Set); ok {
+			values := []string{}
+			for _, value := range set.List() {
+				values = append(values, value.(string))
+			}
+			body[field] = values
+		} else {
+			body[field] = d.Get(attribute)
+		}
+	}
+	return body
+}
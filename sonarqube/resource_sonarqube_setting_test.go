@@ -2,8 +2,12 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -126,3 +130,217 @@ func TestAccSonarqubeSettingMultipleFields(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeSettingComponentConfig(rnd string, key string, value string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_setting" "%[1]s" {
+			component = sonarqube_project.%[1]s.project
+			key       = "%[2]s"
+			value     = "%[3]s"
+		}`, rnd, key, value)
+}
+
+func TestAccSonarqubeSettingComponent(t *testing.T) {
+	key := "sonar.scm.disabled" // Needs to be a setting that can be set on a project
+	rnd := generateRandomResourceName()
+	name := "sonarqube_setting." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeSettingComponentConfig(rnd, key, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", rnd+"/"+key),
+					resource.TestCheckResourceAttr(name, "component", rnd),
+					resource.TestCheckResourceAttr(name, "key", key),
+					resource.TestCheckResourceAttr(name, "value", "true"),
+					resource.TestCheckResourceAttr(name, "inherited", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     rnd + "/" + key,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeSettingSecuredConfig(rnd string, key string, securedValue string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_setting" "%[1]s" {
+			key           = "%[2]s"
+			secured_value = "%[3]s"
+		}`, rnd, key, securedValue)
+}
+
+func TestAccSonarqubeSettingSecured(t *testing.T) {
+	key := "sonar.auth.github.clientSecret.secured" // Needs to be a secured setting
+	rnd := generateRandomResourceName()
+	name := "sonarqube_setting." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeSettingBasicConfig(rnd, key, "secret"),
+				ExpectError: regexp.MustCompile("its value must be set with secured_value"),
+			},
+			{
+				Config: testAccSonarqubeSettingSecuredConfig(rnd, key, "secret"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", key),
+					resource.TestCheckResourceAttr(name, "secured_value", "secret"),
+				),
+			},
+			{
+				Config: testAccSonarqubeSettingSecuredConfig(rnd, key, "secret2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "secured_value", "secret2"),
+				),
+			},
+			{
+				// The value cannot be read back, but a secured setting reset outside of Terraform is set again
+				PreConfig: func() {
+					if err := resetSettings("", []string{key}, testAccProvider.Meta()); err != nil {
+						t.Fatalf("Failed to reset the setting outside of Terraform: %v", err)
+					}
+				},
+				Config:             testAccSonarqubeSettingSecuredConfig(rnd, key, "secret2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestEncryptSettingValue(t *testing.T) {
+	tests := []struct {
+		name               string
+		secretKeyAvailable bool
+		expectedValue      string
+		expectError        bool
+	}{
+		{
+			name:               "secret key available",
+			secretKeyAvailable: true,
+			expectedValue:      "{aes-gcm}encrypted",
+			expectError:        false,
+		},
+		{
+			name:               "secret key unavailable",
+			secretKeyAvailable: false,
+			expectError:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encryptCalled := false
+			host := testStubServer(t, map[string]http.HandlerFunc{
+				"/api/settings/check_secret_key": testStubResponse(http.StatusOK, fmt.Sprintf(`{"secretKeyAvailable": %t}`, tt.secretKeyAvailable)),
+				"/api/settings/encrypt": func(w http.ResponseWriter, r *http.Request) {
+					encryptCalled = true
+					if r.Method != http.MethodPost {
+						t.Errorf("Expected a POST request, got %s", r.Method)
+					}
+					if r.URL.Query().Get("value") != "s3cr3t;value" {
+						t.Errorf("Expected value %q, got %q", "s3cr3t;value", r.URL.Query().Get("value"))
+					}
+					_, _ = w.Write([]byte(`{"encryptedValue": "{aes-gcm}encrypted"}`))
+				},
+			})
+
+			value, err := encryptSettingValue("s3cr3t;value", testProviderConfiguration(t, host, "10.5"))
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "no secret key is available") {
+					t.Errorf("Expected a missing secret key error, got: %v", err)
+				}
+				if encryptCalled {
+					t.Errorf("Expected the value not to be sent to api/settings/encrypt")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if value != tt.expectedValue {
+				t.Errorf("Expected encrypted value %q, got %q", tt.expectedValue, value)
+			}
+		})
+	}
+}
+
+// Authentication resources backed by settings are removed from state once their required settings are reset
+func TestReadSettingAttributesRequired(t *testing.T) {
+	tests := []struct {
+		name       string
+		resource   *schema.Resource
+		read       schema.ReadFunc
+		id         string
+		body       string
+		expectedId string
+	}{
+		{
+			name:       "saml provider id is set",
+			resource:   resourceSonarqubeAuthSaml(),
+			read:       resourceSonarqubeAuthSamlRead,
+			id:         samlAuthenticationSettingsId,
+			body:       `{"settings": [{"key": "sonar.auth.saml.providerId", "value": "https://idp.example.org"}], "setSecuredSettings": []}`,
+			expectedId: samlAuthenticationSettingsId,
+		},
+		{
+			name:       "saml provider id is unset",
+			resource:   resourceSonarqubeAuthSaml(),
+			read:       resourceSonarqubeAuthSamlRead,
+			id:         samlAuthenticationSettingsId,
+			body:       `{"settings": [{"key": "sonar.auth.saml.enabled", "value": "false", "inherited": true}], "setSecuredSettings": []}`,
+			expectedId: "",
+		},
+		{
+			name:       "github client id is set",
+			resource:   resourceSonarqubeAuthGithub(),
+			read:       resourceSonarqubeAuthGithubRead,
+			id:         githubAuthenticationSettingsId,
+			body:       `{"settings": [], "setSecuredSettings": ["sonar.auth.github.clientId.secured"]}`,
+			expectedId: githubAuthenticationSettingsId,
+		},
+		{
+			name:       "github client id is unset",
+			resource:   resourceSonarqubeAuthGithub(),
+			read:       resourceSonarqubeAuthGithubRead,
+			id:         githubAuthenticationSettingsId,
+			body:       `{"settings": [], "setSecuredSettings": ["sonar.auth.github.clientSecret.secured"]}`,
+			expectedId: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := testStubServer(t, map[string]http.HandlerFunc{
+				"/api/settings/values": testStubResponse(http.StatusOK, tt.body),
+			})
+
+			d := schema.TestResourceDataRaw(t, tt.resource.Schema, map[string]interface{}{})
+			d.SetId(tt.id)
+
+			// The settings are only used before the v2 authentication api of SonarQube 10.5
+			if err := tt.read(d, testProviderConfiguration(t, host, "10.4")); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if d.Id() != tt.expectedId {
+				t.Errorf("Expected id %q, got %q", tt.expectedId, d.Id())
+			}
+		})
+	}
+}
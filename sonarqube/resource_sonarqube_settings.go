@@ -0,0 +1,335 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ID used by the bulk settings resource when it manages the global settings
+const globalSettingsId = "global"
+
+// Prefixes of the server and license settings which are never reset by an authoritative sonarqube_settings resource
+var authoritativeSettingsIgnoredPrefixes = []string{"sonar.core.", "sonar.license."}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeBulkSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Sonarqube Settings resource. This can be used to manage many global (or component) Sonarqube settings at once. All settings are read with a single API call.",
+		Create:      resourceSonarqubeBulkSettingsCreate,
+		Read:        resourceSonarqubeBulkSettingsRead,
+		Update:      resourceSonarqubeBulkSettingsUpdate,
+		Delete:      resourceSonarqubeBulkSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeBulkSettingsImport,
+		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateBulkSettingsResource(d)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"component": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Key of the component (project, portfolio or application) to manage the settings of. When unset, the global settings are managed. Changing this forces a new resource to be created.",
+			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, every setting of the component (or every global setting) that is not declared in a `setting` block and is not at its default value is reset. Secured settings, server settings (`sonar.core.*`), license settings (`sonar.license.*`) and the settings listed in `ignore_keys` are never reset. Defaults to `false`.",
+			},
+			"ignore_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Keys of the settings which are never reset when `authoritative` is set, such as settings managed by other resources.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"setting": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The settings to manage. Exactly one of `value`, `values`, `field_values` or `secured_value` must be set for each setting. Secured settings (with a key ending in `.secured`) must use `secured_value`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Setting key",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Setting a value for the supplied key",
+						},
+						"secured_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Setting value of a secured setting. The value is write-only: it cannot be read back, so changes made outside of Terraform are not detected.",
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Setting multi values for the supplied key",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"field_values": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Setting field values for the supplied key",
+							Elem: &schema.Schema{
+								Type: schema.TypeMap,
+								Elem: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate that every setting is declared once, with exactly one kind of value
+func validateBulkSettingsResource(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("setting") {
+		return nil
+	}
+
+	keys := []string{}
+	for _, s := range d.Get("setting").(*schema.Set).List() {
+		setting := s.(map[string]interface{})
+		key := setting["key"].(string)
+		if slices.Contains(keys, key) {
+			return fmt.Errorf("validateBulkSettingsResource: setting '%s' is declared more than once", key)
+		}
+		keys = append(keys, key)
+
+		count := 0
+		if setting["value"].(string) != "" {
+			count++
+		}
+		if len(setting["values"].([]interface{})) > 0 {
+			count++
+		}
+		if len(setting["field_values"].([]interface{})) > 0 {
+			count++
+		}
+		if setting["secured_value"].(string) != "" {
+			count++
+			if !isSecuredSetting(key) {
+				return fmt.Errorf("validateBulkSettingsResource: secured_value can only be set for secured settings, but setting '%s' is not secured", key)
+			}
+		} else if isSecuredSetting(key) {
+			return fmt.Errorf("validateBulkSettingsResource: setting '%s' is secured, its value must be set with secured_value", key)
+		}
+		if count != 1 {
+			return fmt.Errorf("validateBulkSettingsResource: exactly one of value, values, field_values or secured_value must be set for setting '%s'", key)
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeBulkSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeBulkSettings(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeBulkSettingsCreate: Failed to synchronize settings: %+v", err)
+	}
+
+	if component := d.Get("component").(string); component != "" {
+		d.SetId(component)
+	} else {
+		d.SetId(globalSettingsId)
+	}
+	return resourceSonarqubeBulkSettingsRead(d, m)
+}
+
+func resourceSonarqubeBulkSettingsRead(d *schema.ResourceData, m interface{}) error {
+	apiSettings, err := getSettings(d.Get("component").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeBulkSettingsRead: Failed to read settings: %+v", err)
+	}
+
+	var includeUndeclared func(key string) bool
+	if d.Get("authoritative").(bool) {
+		ignoreKeys := expandStringSet(d.Get("ignore_keys").(*schema.Set))
+		includeUndeclared = func(key string) bool { return !isAuthoritativeSettingIgnored(key, ignoreKeys) }
+	}
+	settings := flattenBulkSettings(d.Get("setting").(*schema.Set).List(), apiSettings, includeUndeclared)
+	return d.Set("setting", settings)
+}
+
+func resourceSonarqubeBulkSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := synchronizeBulkSettings(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeBulkSettingsUpdate: Failed to synchronize settings: %+v", err)
+	}
+	return resourceSonarqubeBulkSettingsRead(d, m)
+}
+
+func resourceSonarqubeBulkSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	keys := []string{}
+	for _, s := range d.Get("setting").(*schema.Set).List() {
+		keys = append(keys, s.(map[string]interface{})["key"].(string))
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := resetSettings(d.Get("component").(string), keys, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeBulkSettingsDelete: Failed to reset settings: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeBulkSettingsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	component := ""
+	if d.Id() != globalSettingsId {
+		component = d.Id()
+	}
+
+	apiSettings, err := getSettings(component, m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeBulkSettingsImport: Failed to read settings: %+v", err)
+	}
+
+	// Import every setting that is explicitly set, since there is no configuration to compare with yet
+	if err := d.Set("component", component); err != nil {
+		return nil, err
+	}
+	if err := d.Set("authoritative", false); err != nil {
+		return nil, err
+	}
+	if err := d.Set("setting", flattenBulkSettings(nil, apiSettings, func(string) bool { return true })); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// synchronizeBulkSettings sets every declared setting that differs from the API and resets the settings that should no longer be set
+func synchronizeBulkSettings(d *schema.ResourceData, m interface{}) error {
+	component := d.Get("component").(string)
+	apiSettings, err := getSettings(component, m)
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("setting")
+	oldSettings := old.(*schema.Set).List()
+
+	changed := false
+	declaredKeys := []string{}
+	for _, s := range d.Get("setting").(*schema.Set).List() {
+		setting := s.(map[string]interface{})
+		key := setting["key"].(string)
+		declaredKeys = append(declaredKeys, key)
+
+		if isSecuredSetting(key) {
+			// The value of secured settings cannot be read, so we compare with the previously applied value
+			unchanged := slices.ContainsFunc(oldSettings, func(o interface{}) bool {
+				oldSetting := o.(map[string]interface{})
+				return oldSetting["key"].(string) == key && oldSetting["secured_value"] == setting["secured_value"]
+			})
+			if unchanged && slices.Contains(apiSettings.SetSecuredSettings, key) {
+				continue
+			}
+			if err := setComponentSetting(component, setting, m, &changed); err != nil {
+				return fmt.Errorf("synchronizeBulkSettings: Failed to set setting '%s': %+v", key, err)
+			}
+			continue
+		}
+
+		index := slices.IndexFunc(apiSettings.Setting, func(apiSetting Setting) bool { return apiSetting.Key == key })
+		if index == -1 || checkSettingDiff(setting, apiSettings.Setting[index]) {
+			if err := setComponentSetting(component, setting, m, &changed); err != nil {
+				return fmt.Errorf("synchronizeBulkSettings: Failed to set setting '%s': %+v", key, err)
+			}
+		}
+	}
+
+	toReset := []string{}
+	if d.Get("authoritative").(bool) {
+		ignoreKeys := expandStringSet(d.Get("ignore_keys").(*schema.Set))
+		for _, apiSetting := range apiSettings.Setting {
+			if !apiSetting.Inherited && !slices.Contains(declaredKeys, apiSetting.Key) && !isAuthoritativeSettingIgnored(apiSetting.Key, ignoreKeys) {
+				toReset = append(toReset, apiSetting.Key)
+			}
+		}
+	} else {
+		// Only reset the settings that were previously managed by this resource
+		for _, s := range oldSettings {
+			key := s.(map[string]interface{})["key"].(string)
+			if !slices.Contains(declaredKeys, key) && !slices.Contains(toReset, key) {
+				toReset = append(toReset, key)
+			}
+		}
+	}
+
+	if len(toReset) > 0 {
+		if err := resetSettings(component, toReset, m); err != nil {
+			return fmt.Errorf("synchronizeBulkSettings: Failed to reset settings %v: %+v", toReset, err)
+		}
+	}
+	return nil
+}
+
+// flattenBulkSettings returns the API value of every declared setting, keeping the declared value when there is no difference.
+// When includeUndeclared is set, the settings which are explicitly set but not declared are returned as well when it returns true for their key.
+func flattenBulkSettings(declared []interface{}, apiSettings *GetSettings, includeUndeclared func(key string) bool) []interface{} {
+	settings := []interface{}{}
+	declaredKeys := []string{}
+
+	for _, s := range declared {
+		setting := s.(map[string]interface{})
+		key := setting["key"].(string)
+		declaredKeys = append(declaredKeys, key)
+
+		// Secured settings are write-only, we can only check that they are still set
+		if isSecuredSetting(key) {
+			if slices.Contains(apiSettings.SetSecuredSettings, key) {
+				settings = append(settings, setting)
+			}
+			continue
+		}
+
+		for _, apiSetting := range apiSettings.Setting {
+			// Values inherited from the parent scope are left out, so that the declared setting shows up as a change
+			if key == apiSetting.Key && !apiSetting.Inherited {
+				if checkSettingDiff(setting, apiSetting) {
+					settings = append(settings, apiSetting.ToMap())
+				} else {
+					settings = append(settings, setting)
+				}
+				break
+			}
+		}
+	}
+
+	if includeUndeclared != nil {
+		for _, apiSetting := range apiSettings.Setting {
+			if !apiSetting.Inherited && !slices.Contains(declaredKeys, apiSetting.Key) && includeUndeclared(apiSetting.Key) {
+				settings = append(settings, apiSetting.ToMap())
+			}
+		}
+	}
+
+	return settings
+}
+
+// isAuthoritativeSettingIgnored returns true when an undeclared setting must be left alone by an authoritative resource
+func isAuthoritativeSettingIgnored(key string, ignoreKeys []string) bool {
+	if isSecuredSetting(key) || slices.Contains(ignoreKeys, key) {
+		return true
+	}
+	return slices.ContainsFunc(authoritativeSettingsIgnoredPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) })
+}
@@ -0,0 +1,171 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_settings", &resource.Sweeper{
+		Name: "sonarqube_settings",
+		F:    testSweepSonarqubeSettingsSweeper,
+	})
+}
+
+func testSweepSonarqubeSettingsSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeSettingsGlobalConfig(rnd string, value string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_settings" "%[1]s" {
+			setting {
+				key   = "sonar.demo"
+				value = "%[2]s"
+			}
+			setting {
+				key    = "sonar.global.exclusions"
+				values = ["foo", "bar"]
+			}
+			setting {
+				key           = "email.smtp_password.secured"
+				secured_value = "%[2]s"
+			}
+		}`, rnd, value)
+}
+
+func testAccSonarqubeSettingsComponentConfig(rnd string, withExclusions bool) string {
+	exclusions := ""
+	if withExclusions {
+		exclusions = `
+			setting {
+				key    = "sonar.exclusions"
+				values = ["foo", "bar"]
+			}`
+	}
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_settings" "%[1]s" {
+			component     = sonarqube_project.%[1]s.project
+			authoritative = true
+
+			setting {
+				key   = "sonar.scm.disabled"
+				value = "true"
+			}%[2]s
+		}`, rnd, exclusions)
+}
+
+func TestAccSonarqubeSettingsGlobal(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_settings." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeSettingsGlobalConfig(rnd, "sonarqube@example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "global"),
+					resource.TestCheckResourceAttr(name, "setting.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "setting.*", map[string]string{
+						"key":   "sonar.demo",
+						"value": "sonarqube@example.org",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeSettingsGlobalConfig(rnd, "sonarqube2@example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "setting.*", map[string]string{
+						"key":   "sonar.demo",
+						"value": "sonarqube2@example.org",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "setting.*", map[string]string{
+						"key":           "email.smtp_password.secured",
+						"secured_value": "sonarqube2@example.org",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeSettingsComponentAuthoritative(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_settings." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeSettingsComponentConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", rnd),
+					resource.TestCheckResourceAttr(name, "component", rnd),
+					resource.TestCheckResourceAttr(name, "setting.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeSettingsComponentConfig(rnd, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "setting.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "setting.*", map[string]string{
+						"key":   "sonar.scm.disabled",
+						"value": "true",
+					}),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authoritative"},
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeSettingsGlobalAuthoritative(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_settings." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_setting" "%[1]s" {
+						key    = "sonar.global.exclusions"
+						values = ["foo", "bar"]
+					}
+
+					resource "sonarqube_settings" "%[1]s" {
+						authoritative = true
+						ignore_keys   = [sonarqube_setting.%[1]s.key]
+
+						setting {
+							key   = "sonar.demo"
+							value = "sonarqube@example.org"
+						}
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "global"),
+					// The setting managed by sonarqube_setting is ignored, so it is neither reset nor read
+					resource.TestCheckResourceAttr(name, "setting.#", "1"),
+					resource.TestCheckResourceAttr("sonarqube_setting."+rnd, "values.#", "2"),
+				),
+			},
+		},
+	})
+}
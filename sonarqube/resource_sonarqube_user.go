@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,17 +10,25 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // User struct
 type User struct {
-	Login       string   `json:"login,omitempty"`
-	Name        string   `json:"name,omitempty"`
-	Email       string   `json:"email,omitempty"`
-	Permissions []string `json:"permissions,omitempty"`
-	IsActive    bool     `json:"active,omitempty"`
-	IsLocal     bool     `json:"local,omitempty"`
+	Login              string   `json:"login,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	Email              string   `json:"email,omitempty"`
+	Permissions        []string `json:"permissions,omitempty"`
+	IsActive           bool     `json:"active,omitempty"`
+	IsLocal            bool     `json:"local,omitempty"`
+	ScmAccounts        []string `json:"scmAccounts,omitempty"`
+	IsManaged          bool     `json:"managed,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	LastConnectionDate string   `json:"lastConnectionDate,omitempty"`
+	ExternalIdentity   string   `json:"externalIdentity,omitempty"`
+	ExternalProvider   string   `json:"externalProvider,omitempty"`
 }
 
 // GetUser for unmarshalling response body where users are retured
@@ -33,10 +42,28 @@ type CreateUserResponse struct {
 	User User `json:"user"`
 }
 
+// UserV2 struct, as returned by the v2 users-management api
+type UserV2 struct {
+	ID          string   `json:"id"`
+	Login       string   `json:"login"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	IsActive    bool     `json:"active"`
+	IsLocal     bool     `json:"local"`
+	IsManaged   bool     `json:"managed"`
+	ScmAccounts []string `json:"scmAccounts"`
+}
+
+// GetUsersV2 for unmarshalling response body of api/v2/users-management/users
+type GetUsersV2 struct {
+	Page  Paging   `json:"page"`
+	Users []UserV2 `json:"users"`
+}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeUser() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube User resource. This can be used to manage Sonarqube Users.",
+		Description: "Provides a Sonarqube User resource. This can be used to manage Sonarqube Users. Users are deactivated on destroy, and a deactivated User with the same login is reactivated on creation.",
 		Create:      resourceSonarqubeUserCreate,
 		Read:        resourceSonarqubeUserRead,
 		Update:      resourceSonarqubeUserUpdate,
@@ -44,6 +71,18 @@ func resourceSonarqubeUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeUserImport,
 		},
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateUserNotManaged(d)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return setUserAnonymizeOnDestroy(d, meta.(*ProviderConfiguration))
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return setUserScmAccountsCleared(d)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -56,8 +95,7 @@ func resourceSonarqubeUser() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the User to create. Changing this forces a new resource to be created.",
+				Description: "The name of the User to create.",
 			},
 			"email": {
 				Type:        schema.TypeString,
@@ -77,11 +115,104 @@ func resourceSonarqubeUser() *schema.Resource {
 				ForceNew:    true,
 				Description: "`True` if the User should be of type `local`. Defaults to `true`.",
 			},
+			"scm_accounts": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The SCM accounts of the User, such as the login or email addresses used in commits, so that issues are assigned to the right User. When unset, the SCM accounts of the User are not managed, so accounts added in SonarQube are kept. Set it to an empty list to remove every SCM account.",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the User is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning). Managed Users cannot be modified or deactivated from Terraform.",
+			},
+			"anonymize_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the User is anonymized when it is deactivated on destroy. Defaults to the `anonymize_user_on_delete` provider setting. Requires SonarQube 9.7 or above.",
+			},
 		},
 	}
 }
 
+// managedUserError explains why a user which is managed by an external provisioning system cannot be changed
+func managedUserError(login string, action string) error {
+	return fmt.Errorf("user '%s' is managed by an external provisioning system (SCIM, GitHub or GitLab provisioning) and cannot be %s from Terraform: make the change in the identity provider instead", login, action)
+}
+
+// Validate that a user which is managed by an external provisioning system is not modified
+func validateUserNotManaged(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.Get("managed").(bool) {
+		return nil
+	}
+	if d.HasChanges("name", "email", "password", "scm_accounts") {
+		return managedUserError(d.Id(), "modified")
+	}
+	return nil
+}
+
+// Follow the anonymize_user_on_delete provider setting when anonymize_on_destroy is not set
+func setUserAnonymizeOnDestroy(d *schema.ResourceDiff, conf *ProviderConfiguration) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	if rawAnonymize := rawConfig.GetAttr("anonymize_on_destroy"); !rawAnonymize.IsNull() {
+		if rawAnonymize.IsKnown() && rawAnonymize.True() {
+			if err := checkUserAnonymizeSupport(conf); err != nil {
+				return fmt.Errorf("setUserAnonymizeOnDestroy: anonymize_on_destroy cannot be enabled: %+v", err)
+			}
+		}
+		return nil
+	}
+	if d.Id() == "" || d.Get("anonymize_on_destroy").(bool) != conf.sonarQubeAnonymizeUsers {
+		return d.SetNew("anonymize_on_destroy", conf.sonarQubeAnonymizeUsers)
+	}
+	return nil
+}
+
+func checkUserAnonymizeSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("9.7")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for anonymizing users is %s", minimumVersion)
+	}
+	return nil
+}
+
+// scm_accounts is Optional+Computed so that accounts added in SonarQube are kept when it is unset.
+// An explicitly empty scm_accounts would be ignored as well, so it is planned here to clear the accounts.
+func setUserScmAccountsCleared(d *schema.ResourceDiff) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	scmAccounts := rawConfig.GetAttr("scm_accounts")
+	if scmAccounts.IsNull() || !scmAccounts.IsKnown() || scmAccounts.LengthInt() > 0 {
+		return nil
+	}
+	if old, _ := d.GetChange("scm_accounts"); d.Id() != "" && old.(*schema.Set).Len() > 0 {
+		return d.SetNew("scm_accounts", []interface{}{})
+	}
+	return nil
+}
+
 func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		// Unlike api/users/create, the v2 api cannot reactivate a deactivated user with the same login
+		user, err := searchUserV2FromApi(d.Get("login_name").(string), false, m)
+		if err != nil {
+			return fmt.Errorf("error creating Sonarqube user: %+v", err)
+		}
+		if user == nil {
+			return resourceSonarqubeUserCreateV2(d, m)
+		}
+	}
+
+	// A deactivated user with the same login is reactivated
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/create"
 
@@ -101,6 +232,10 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 		rawQuery.Add("email", email.(string))
 	}
 
+	for _, scmAccount := range expandScmAccounts(d) {
+		rawQuery.Add("scmAccount", scmAccount)
+	}
+
 	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
@@ -132,6 +267,10 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeUserReadV2(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
 
@@ -168,6 +307,8 @@ func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
 			errs = append(errs, d.Set("name", value.Name))
 			errs = append(errs, d.Set("email", value.Email))
 			errs = append(errs, d.Set("is_local", value.IsLocal))
+			errs = append(errs, d.Set("scm_accounts", value.ScmAccounts))
+			errs = append(errs, d.Set("managed", value.IsManaged))
 			return errors.Join(errs...)
 		}
 	}
@@ -176,15 +317,34 @@ func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURLSubPath := sonarQubeURL.Path
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return resourceSonarqubeUserUpdateV2(d, m)
+	}
+
 	// handle default updates (api/users/update)
-	if d.HasChange("email") {
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/update"
-		sonarQubeURL.RawQuery = url.Values{
+	if d.HasChanges("name", "email", "scm_accounts") {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/update"
+		rawQuery := url.Values{
 			"login": []string{d.Id()},
-			"email": []string{d.Get("email").(string)},
-		}.Encode()
+		}
+		if d.HasChange("name") {
+			rawQuery.Add("name", d.Get("name").(string))
+		}
+		if d.HasChange("email") {
+			rawQuery.Add("email", d.Get("email").(string))
+		}
+		if d.HasChange("scm_accounts") {
+			// The SCM accounts are replaced as a whole, an empty value removes all of them
+			scmAccounts := expandScmAccounts(d)
+			if len(scmAccounts) == 0 {
+				rawQuery.Add("scmAccount", "")
+			}
+			for _, scmAccount := range scmAccounts {
+				rawQuery.Add("scmAccount", scmAccount)
+			}
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
 
 		resp, err := httpRequestHelper(
 			m.(*ProviderConfiguration).httpClient,
@@ -199,38 +359,65 @@ func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 		defer resp.Body.Close()
 	}
 
-	// handle password updates (api/users/change_password)
-	if d.HasChange("password") {
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURLSubPath, "/") + "/api/users/change_password"
-		oldPassword, newPassword := d.GetChange("password")
-		sonarQubeURL.RawQuery = url.Values{
-			"login":            []string{d.Id()},
-			"password":         []string{newPassword.(string)},
-			"previousPassword": []string{oldPassword.(string)},
-		}.Encode()
-
-		resp, err := httpRequestHelper(
-			m.(*ProviderConfiguration).httpClient,
-			"POST",
-			sonarQubeURL.String(),
-			http.StatusNoContent,
-			"resourceSonarqubeUserUpdate",
-		)
-		if err != nil {
-			return fmt.Errorf("error updating Sonarqube user: %+v", err)
-		}
-		defer resp.Body.Close()
+	if err := updateUserPassword(d, m); err != nil {
+		return err
 	}
 
 	return resourceSonarqubeUserRead(d, m)
 }
 
+// updateUserPassword handles password updates (api/users/change_password), which have no equivalent in the v2 api
+func updateUserPassword(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange("password") {
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/change_password"
+	oldPassword, newPassword := d.GetChange("password")
+	sonarQubeURL.RawQuery = url.Values{
+		"login":            []string{d.Id()},
+		"password":         []string{newPassword.(string)},
+		"previousPassword": []string{oldPassword.(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeUserUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("error updating Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("managed").(bool) {
+		return managedUserError(d.Id(), "deactivated")
+	}
+
+	if err := deactivateUser(d.Id(), d.Get("anonymize_on_destroy").(bool), m); err != nil {
+		return fmt.Errorf("error deleting (deactivating) Sonarqube user: %+v", err)
+	}
+	return nil
+}
+
+// deactivateUser deactivates the user with the given login, and anonymizes it when anonymize is true
+func deactivateUser(login string, anonymize bool, m interface{}) error {
+	if useV2Api(m.(*ProviderConfiguration)) {
+		return deactivateUserV2(login, anonymize, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/deactivate"
 	sonarQubeURL.RawQuery = url.Values{
-		"login":     []string{d.Id()},
-		"anonymize": []string{strconv.FormatBool(m.(*ProviderConfiguration).sonarQubeAnonymizeUsers)},
+		"login":     []string{login},
+		"anonymize": []string{strconv.FormatBool(anonymize)},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -238,10 +425,10 @@ func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
 		"POST",
 		sonarQubeURL.String(),
 		http.StatusOK,
-		"resourceSonarqubeUserDelete",
+		"deactivateUser",
 	)
 	if err != nil {
-		return fmt.Errorf("error deleting (deactivating) Sonarqube user: %+v", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -252,5 +439,214 @@ func resourceSonarqubeUserImport(d *schema.ResourceData, m interface{}) ([]*sche
 	if err := resourceSonarqubeUserRead(d, m); err != nil {
 		return nil, err
 	}
+	if err := d.Set("anonymize_on_destroy", m.(*ProviderConfiguration).sonarQubeAnonymizeUsers); err != nil {
+		return nil, err
+	}
 	return []*schema.ResourceData{d}, nil
 }
+
+func resourceSonarqubeUserCreateV2(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users"
+
+	body := map[string]interface{}{
+		"login": d.Get("login_name").(string),
+		"name":  d.Get("name").(string),
+		"local": d.Get("is_local").(bool),
+	}
+	if password, ok := d.GetOk("password"); ok {
+		body["password"] = password.(string)
+	}
+	if email, ok := d.GetOk("email"); ok {
+		body["email"] = email.(string)
+	}
+	if scmAccounts := expandScmAccounts(d); len(scmAccounts) > 0 {
+		body["scmAccounts"] = scmAccounts
+	}
+
+	resp, err := httpRequestHelperWithJson(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		body,
+		http.StatusOK,
+		"resourceSonarqubeUserCreateV2",
+	)
+	if err != nil {
+		return fmt.Errorf("error creating Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	userResponse := UserV2{}
+	err = json.NewDecoder(resp.Body).Decode(&userResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeUserCreateV2: Failed to decode json into struct: %+v", err)
+	}
+
+	if userResponse.Login != "" {
+		d.SetId(userResponse.Login)
+	} else {
+		return fmt.Errorf("resourceSonarqubeUserCreateV2: Create response didn't contain the user login")
+	}
+
+	return resourceSonarqubeUserReadV2(d, m)
+}
+
+func resourceSonarqubeUserReadV2(d *schema.ResourceData, m interface{}) error {
+	user, err := readUserV2FromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("error reading Sonarqube user: %+v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("resourceSonarqubeUserReadV2: Failed to find user: %+v", d.Id())
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("login_name", user.Login))
+	errs = append(errs, d.Set("name", user.Name))
+	errs = append(errs, d.Set("email", user.Email))
+	errs = append(errs, d.Set("is_local", user.IsLocal))
+	errs = append(errs, d.Set("scm_accounts", user.ScmAccounts))
+	errs = append(errs, d.Set("managed", user.IsManaged))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeUserUpdateV2(d *schema.ResourceData, m interface{}) error {
+	if d.HasChanges("name", "email", "scm_accounts") {
+		user, err := readUserV2FromApi(d.Id(), m)
+		if err != nil {
+			return fmt.Errorf("error updating Sonarqube user: %+v", err)
+		}
+		if user == nil {
+			return fmt.Errorf("resourceSonarqubeUserUpdateV2: Failed to find user: %+v", d.Id())
+		}
+
+		body := map[string]interface{}{}
+		if d.HasChange("name") {
+			body["name"] = d.Get("name").(string)
+		}
+		if d.HasChange("email") {
+			body["email"] = d.Get("email").(string)
+		}
+		if d.HasChange("scm_accounts") {
+			body["scmAccounts"] = expandScmAccounts(d)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users/" + url.PathEscape(user.ID)
+
+		resp, err := httpRequestHelperWithJson(
+			m.(*ProviderConfiguration).httpClient,
+			"PATCH",
+			sonarQubeURL.String(),
+			body,
+			http.StatusOK,
+			"resourceSonarqubeUserUpdateV2",
+		)
+		if err != nil {
+			return fmt.Errorf("error updating Sonarqube user: %+v", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	if err := updateUserPassword(d, m); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeUserReadV2(d, m)
+}
+
+func deactivateUserV2(login string, anonymize bool, m interface{}) error {
+	user, err := readUserV2FromApi(login, m)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		// The user is already deactivated
+		return nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users/" + url.PathEscape(user.ID)
+	sonarQubeURL.RawQuery = url.Values{
+		"anonymize": []string{strconv.FormatBool(anonymize)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"DELETE",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deactivateUserV2",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// readUserV2FromApi returns the active user with the given login, or nil if there is no such user
+func readUserV2FromApi(login string, m interface{}) (*UserV2, error) {
+	return searchUserV2FromApi(login, true, m)
+}
+
+// searchUserV2FromApi returns the active or deactivated user with the given login, or nil if there is no such user
+func searchUserV2FromApi(login string, active bool, m interface{}) (*UserV2, error) {
+	pageIndex := 1
+	pageSize := 500
+	found := 0
+
+	for {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users"
+		sonarQubeURL.RawQuery = url.Values{
+			"q":         []string{login},
+			"active":    []string{strconv.FormatBool(active)},
+			"pageIndex": []string{strconv.Itoa(pageIndex)},
+			"pageSize":  []string{strconv.Itoa(pageSize)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"searchUserV2FromApi",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		usersResponse := GetUsersV2{}
+		if err := json.NewDecoder(resp.Body).Decode(&usersResponse); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("searchUserV2FromApi: Failed to decode json into struct: %+v", err)
+		}
+		_ = resp.Body.Close()
+
+		// The search also matches on name and email, so look for the exact login
+		for _, user := range usersResponse.Users {
+			if user.Login == login {
+				return &user, nil
+			}
+		}
+
+		found += len(usersResponse.Users)
+		if int64(found) >= usersResponse.Page.Total || len(usersResponse.Users) == 0 {
+			return nil, nil
+		}
+		pageIndex++
+	}
+}
+
+// expandScmAccounts returns the declared SCM accounts as a list of strings
+func expandScmAccounts(d *schema.ResourceData) []string {
+	scmAccounts := []string{}
+	for _, scmAccount := range d.Get("scm_accounts").(*schema.Set).List() {
+		scmAccounts = append(scmAccounts, scmAccount.(string))
+	}
+	return scmAccounts
+}
@@ -0,0 +1,210 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// timeNow returns the current time, it is replaced in the tests to look up inactive users deterministically
+var timeNow = time.Now
+
+// Returns the resource represented by this file.
+func resourceSonarqubeUserLifecyclePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube User Lifecycle Policy resource. This can be used to deactivate the local Users which did not connect for a number of days.
+
+The inactive Users are looked up on every plan, and the plan lists their logins in ` + "`deactivated_users`" + `. They are deactivated on apply.
+Managed Users, Users which never connected and the User of the provider credentials are never deactivated. Requires SonarQube 10.1 or later.
+Users reactivated outside of Terraform are deactivated again by the next apply when they are still inactive.
+Destroying this resource only removes it from the state, deactivated Users are not reactivated.`,
+		Create: resourceSonarqubeUserLifecyclePolicyCreate,
+		Read:   resourceSonarqubeUserLifecyclePolicyRead,
+		Update: resourceSonarqubeUserLifecyclePolicyUpdate,
+		Delete: resourceSonarqubeUserLifecyclePolicyDelete,
+		// Validation that runs after the read in plan has completed (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/customizing-differences)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return planUserLifecyclePolicy(d, meta)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"inactive_days": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				Description:      "Users whose last connection is older than this number of days are deactivated.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"exclude_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Members of these groups are never deactivated.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"exclude_logins": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Users with these logins are never deactivated, such as service accounts.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"anonymize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the deactivated Users are anonymized as well. Defaults to `false`.",
+			},
+			"deactivated_users": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The logins of the Users deactivated by the last apply which are still deactivated. In a plan, the logins of the Users that will be deactivated.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Plan the deactivation of the inactive users, so that their logins show up in the plan
+func planUserLifecyclePolicy(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("inactive_days") || !d.NewValueKnown("exclude_groups") || !d.NewValueKnown("exclude_logins") {
+		return nil
+	}
+
+	logins, err := findInactiveUsers(
+		d.Get("inactive_days").(int),
+		expandStringSet(d.Get("exclude_groups").(*schema.Set)),
+		expandStringSet(d.Get("exclude_logins").(*schema.Set)),
+		m,
+	)
+	if err != nil {
+		return fmt.Errorf("planUserLifecyclePolicy: Failed to find inactive users: %+v", err)
+	}
+	// An empty list is planned as well, so that the users of a previous apply are not deactivated again
+	return d.SetNew("deactivated_users", logins)
+}
+
+func resourceSonarqubeUserLifecyclePolicyCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkUsersSearchFiltersSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	if err := deactivateInactiveUsers(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeUserLifecyclePolicyCreate: %+v", err)
+	}
+
+	d.SetId(id.UniqueId())
+	return resourceSonarqubeUserLifecyclePolicyRead(d, m)
+}
+
+func resourceSonarqubeUserLifecyclePolicyRead(d *schema.ResourceData, m interface{}) error {
+	// The policy only exists in the state, the inactive users are looked up in plan.
+	// Users reactivated outside of Terraform are dropped from the state, so that they are planned for deactivation again.
+	logins := []string{}
+	for _, login := range expandStringSet(d.Get("deactivated_users").(*schema.Set)) {
+		active, err := isActiveUser(login, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeUserLifecyclePolicyRead: Failed to read user '%s': %+v", login, err)
+		}
+		if !active {
+			logins = append(logins, login)
+		}
+	}
+	return d.Set("deactivated_users", logins)
+}
+
+func resourceSonarqubeUserLifecyclePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	// The planned list is deactivated as is, it was looked up again for this plan
+	if err := deactivateInactiveUsers(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeUserLifecyclePolicyUpdate: %+v", err)
+	}
+	return resourceSonarqubeUserLifecyclePolicyRead(d, m)
+}
+
+func resourceSonarqubeUserLifecyclePolicyDelete(d *schema.ResourceData, m interface{}) error {
+	// Deactivated users are not reactivated, the policy is only removed from the state
+	return nil
+}
+
+// deactivateInactiveUsers deactivates the users listed in the plan
+func deactivateInactiveUsers(d *schema.ResourceData, m interface{}) error {
+	logins := expandStringSet(d.Get("deactivated_users").(*schema.Set))
+	for _, login := range logins {
+		if err := deactivateUser(login, d.Get("anonymize").(bool), m); err != nil {
+			return fmt.Errorf("deactivateInactiveUsers: Failed to deactivate user '%s': %+v", login, err)
+		}
+	}
+	return d.Set("deactivated_users", logins)
+}
+
+// isActiveUser returns true when the user with the given login exists and is active
+func isActiveUser(login string, m interface{}) (bool, error) {
+	usersReadResponse, err := readUsersFromApi(url.Values{
+		"q":           []string{login},
+		"deactivated": []string{"false"},
+	}, m)
+	if err != nil {
+		return false, err
+	}
+	// The search matches on part of the login, so look for the exact login
+	return slices.ContainsFunc(usersReadResponse.Users, func(user User) bool { return user.Login == login }), nil
+}
+
+// findInactiveUsers returns the logins of the local users which did not connect for inactiveDays, sorted alphabetically
+func findInactiveUsers(inactiveDays int, excludeGroups []string, excludeLogins []string, m interface{}) ([]string, error) {
+	conf := m.(*ProviderConfiguration)
+	if err := checkUsersSearchFiltersSupport(conf); err != nil {
+		return nil, err
+	}
+
+	// Never lock out the provider itself
+	currentUser, err := readCurrentUserFromApi(conf.httpClient, conf.sonarQubeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	usersReadResponse, err := readUsersFromApi(url.Values{
+		"managed":             []string{"false"},
+		"lastConnectedBefore": []string{timeNow().AddDate(0, 0, -inactiveDays).Format("2006-01-02T15:04:05-0700")},
+	}, m)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := []string{}
+	for _, user := range usersReadResponse.Users {
+		// Users which never connected have no last connection to compare with, they are left alone
+		if !user.IsLocal || user.IsManaged || user.LastConnectionDate == "" || user.Login == currentUser.Login {
+			continue
+		}
+		if slices.Contains(excludeLogins, user.Login) || slices.ContainsFunc(user.Groups, func(group string) bool { return slices.Contains(excludeGroups, group) }) {
+			continue
+		}
+		logins = append(logins, user.Login)
+	}
+
+	slices.Sort(logins)
+	return logins, nil
+}
+
+// expandStringSet returns the elements of a set of strings
+func expandStringSet(set *schema.Set) []string {
+	values := []string{}
+	for _, value := range set.List() {
+		values = append(values, value.(string))
+	}
+	return values
+}
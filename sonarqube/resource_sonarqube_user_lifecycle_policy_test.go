@@ -0,0 +1,164 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_user_lifecycle_policy", &resource.Sweeper{
+		Name: "sonarqube_user_lifecycle_policy",
+		F:    testSweepSonarqubeUserLifecyclePolicySweeper,
+	})
+}
+
+func testSweepSonarqubeUserLifecyclePolicySweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeUserLifecyclePolicyConfig(rnd string, inactiveDays int) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[1]s"
+			name       = "%[1]s"
+			email      = "%[1]s@sonarqube.com"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_user_lifecycle_policy" "%[1]s" {
+			inactive_days  = %[2]d
+			exclude_groups = ["sonar-administrators"]
+			exclude_logins = ["admin"]
+
+			depends_on = [sonarqube_user.%[1]s]
+		}
+
+		data "sonarqube_user" "%[1]s" {
+			login_name = sonarqube_user.%[1]s.login_name
+
+			depends_on = [sonarqube_user_lifecycle_policy.%[1]s]
+		}`, rnd, inactiveDays)
+}
+
+func TestAccSonarqubeUserLifecyclePolicy(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user_lifecycle_policy." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if testAccProvider != nil && testAccProvider.Meta() != nil {
+						minimumVersion, _ := version.NewVersion("10.1")
+						if testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion.LessThan(minimumVersion) {
+							t.Skip("Skipping test - user lifecycle policies require SonarQube 10.1 or later")
+						}
+					}
+				},
+				Config: testAccSonarqubeUserLifecyclePolicyConfig(rnd, 180),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "inactive_days", "180"),
+					// The user never connected, so it is left alone
+					resource.TestCheckResourceAttr(name, "deactivated_users.#", "0"),
+					resource.TestCheckResourceAttr("data.sonarqube_user."+rnd, "login_name", rnd),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserLifecyclePolicyConfig(rnd, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "inactive_days", "1"),
+					resource.TestCheckResourceAttr(name, "deactivated_users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeUserLifecyclePolicyDeactivatesInactiveUsers(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	defer func(original func() time.Time) { timeNow = original }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	deactivated := []string{}
+	host := testStubServer(t, map[string]http.HandlerFunc{
+		"/api/users/current": testStubResponse(http.StatusOK, `{"isLoggedIn": true, "login": "admin"}`),
+		"/api/users/search": func(w http.ResponseWriter, r *http.Request) {
+			if login := r.URL.Query().Get("q"); login != "" {
+				// The refresh looks up the deactivated users, which are active again once reactivated
+				if slices.Contains(deactivated, login) {
+					_, _ = w.Write([]byte(`{"paging": {"pageIndex": 1, "pageSize": 500, "total": 0}, "users": []}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"paging": {"pageIndex": 1, "pageSize": 500, "total": 1}, "users": [
+					{"login": "` + login + `", "active": true, "local": true, "groups": ["sonar-users"], "lastConnectionDate": "2024-01-01T00:00:00+0000"}
+				]}`))
+				return
+			}
+			if got, want := r.URL.Query().Get("lastConnectedBefore"), now.AddDate(0, 0, -30).Format("2006-01-02T15:04:05-0700"); got != want {
+				t.Errorf("Expected lastConnectedBefore %s, got %s", want, got)
+			}
+			_, _ = w.Write([]byte(`{"paging": {"pageIndex": 1, "pageSize": 500, "total": 5}, "users": [
+				{"login": "admin", "local": true, "groups": ["sonar-administrators"], "lastConnectionDate": "2024-01-01T00:00:00+0000"},
+				{"login": "inactive", "local": true, "groups": ["sonar-users"], "lastConnectionDate": "2024-01-01T00:00:00+0000"},
+				{"login": "excluded-group", "local": true, "groups": ["robots"], "lastConnectionDate": "2024-01-01T00:00:00+0000"},
+				{"login": "excluded-login", "local": true, "groups": ["sonar-users"], "lastConnectionDate": "2024-01-01T00:00:00+0000"},
+				{"login": "never-connected", "local": true, "groups": ["sonar-users"]}
+			]}`))
+		},
+		"/api/users/deactivate": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected a POST request, got %s", r.Method)
+			}
+			deactivated = append(deactivated, r.URL.Query().Get("login"))
+			_, _ = w.Write([]byte(`{}`))
+		},
+	})
+
+	conf := testProviderConfiguration(t, host, "10.1")
+
+	logins, err := findInactiveUsers(30, []string{"robots"}, []string{"excluded-login"}, conf)
+	if err != nil {
+		t.Fatalf("findInactiveUsers returned an error: %v", err)
+	}
+	if !slices.Equal(logins, []string{"inactive"}) {
+		t.Fatalf("Expected the inactive users [inactive], got %v", logins)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSonarqubeUserLifecyclePolicy().Schema, map[string]interface{}{
+		"inactive_days":  30,
+		"exclude_groups": []interface{}{"robots"},
+		"exclude_logins": []interface{}{"excluded-login"},
+	})
+	d.SetId("policy")
+	if err := d.Set("deactivated_users", logins); err != nil {
+		t.Fatalf("Failed to set deactivated_users: %v", err)
+	}
+
+	if err := resourceSonarqubeUserLifecyclePolicyUpdate(d, conf); err != nil {
+		t.Fatalf("resourceSonarqubeUserLifecyclePolicyUpdate returned an error: %v", err)
+	}
+	if !slices.Equal(deactivated, []string{"inactive"}) {
+		t.Errorf("Expected only [inactive] to be deactivated, got %v", deactivated)
+	}
+	if got := d.Get("deactivated_users").(*schema.Set).Len(); got != 1 {
+		t.Errorf("Expected 1 deactivated user in the state, got %d", got)
+	}
+
+	// A user reactivated outside of Terraform is dropped from the state, so that it is planned for deactivation again
+	deactivated = []string{}
+	if err := resourceSonarqubeUserLifecyclePolicyRead(d, conf); err != nil {
+		t.Fatalf("resourceSonarqubeUserLifecyclePolicyRead returned an error: %v", err)
+	}
+	if got := d.Get("deactivated_users").(*schema.Set).Len(); got != 0 {
+		t.Errorf("Expected the reactivated user to be dropped from the state, got %d deactivated users", got)
+	}
+}
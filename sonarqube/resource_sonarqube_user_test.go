@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func init() {
@@ -101,3 +102,128 @@ func TestAccSonarqubeUserNotLocal(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeUserScmAccountsConfig(rnd string, name string, scmAccounts string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name   = "%[1]s"
+			name         = "%[2]s"
+			email        = "%[1]s@sonarqube.com"
+			is_local     = false
+			scm_accounts = %[3]s
+		}`, rnd, name, scmAccounts)
+}
+
+func TestAccSonarqubeUserUpdateNameAndScmAccounts(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "Test User", `["`+rnd+`@personal.example.org"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "Test User"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", rnd+"@personal.example.org"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "Renamed User", `["`+rnd+`@personal.example.org", "`+rnd+`-github"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "Renamed User"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", rnd+"-github"),
+				),
+			},
+			{
+				// Without scm_accounts, the accounts of the user are kept
+				Config: fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[1]s"
+			name       = "Renamed User"
+			email      = "%[1]s@sonarqube.com"
+			is_local   = false
+		}`, rnd),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserScmAccountsConfig(rnd, "Renamed User", "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "0"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserReactivateConfig(rnd string, withUser bool) string {
+	if !withUser {
+		return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[1]s"
+		}`, rnd)
+	}
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_user" "%[1]s" {
+			login_name           = "%[1]s"
+			name                 = "Reactivated User"
+			email                = "%[1]s@sonarqube.com"
+			is_local             = false
+			anonymize_on_destroy = false
+		}`, rnd)
+}
+
+func TestAccSonarqubeUserReactivate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserReactivateConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_name", rnd),
+					resource.TestCheckResourceAttr(name, "anonymize_on_destroy", "false"),
+				),
+			},
+			{
+				// Deactivates the user, without anonymizing it
+				Config: testAccSonarqubeUserReactivateConfig(rnd, false),
+			},
+			{
+				// Creating the user again reactivates it
+				Config: testAccSonarqubeUserReactivateConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_name", rnd),
+					resource.TestCheckResourceAttr(name, "name", "Reactivated User"),
+				),
+			},
+		},
+	})
+}